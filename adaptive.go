@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// AdaptiveJob 是根据执行结果决定下次执行间隔的任务
+// Run返回正数时，下次执行时间改为本次执行结束后经过该间隔，否则按任务的调度器计算
+// 例如资源未就绪时快速轮询，就绪后放慢频率
+type AdaptiveJob interface {
+	Run() (next time.Duration, err error)
+}
+
+// AdaptiveFuncJob 将函数转换为AdaptiveJob接口实现
+type AdaptiveFuncJob func() (time.Duration, error)
+
+// Run 实现AdaptiveJob接口
+func (f AdaptiveFuncJob) Run() (time.Duration, error) { return f() }
+
+// AddAdaptiveJob 添加一个根据执行结果调整下次执行时间的任务
+// schedule决定首次执行时间，以及任务没有给出间隔时的下次执行时间
+func (c *Cron) AddAdaptiveJob(schedule Schedule, cmd AdaptiveJob, opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, adaptiveJob{job: cmd, c: c}, opts...)
+}
+
+// adaptiveJob 将AdaptiveJob适配为Job，并在执行结束后调整下次执行时间
+type adaptiveJob struct {
+	job AdaptiveJob
+	c   *Cron
+}
+
+// Run 实现Job接口，直接调用时不会调整下次执行时间
+func (j adaptiveJob) Run() {
+	_, _ = j.job.Run()
+}
+
+// isNil 实现nilChecker接口
+func (j adaptiveJob) isNil() bool {
+	return isNilJob(j.job)
+}
+
+// runContext 执行任务，返回的间隔为正数时覆盖下次执行时间
+func (j adaptiveJob) runContext(ctx context.Context) error {
+	next, err := j.job.Run()
+	if next > 0 {
+		j.c.delayNext(entryIDFrom(ctx), next)
+	}
+	return err
+}
+
+// delayNext 将任务的下次执行时间设为当前时间之后d，并唤醒主循环
+// 任务已被删除、暂停或已停止调度时不做修改
+func (c *Cron) delayNext(id EntryID, d time.Duration) {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	e := c.entry(id)
+	if e == nil || e.Paused || e.exhausted() {
+		return
+	}
+	now := c.now()
+	e.Next = now.Add(d)
+	c.logger.Info("adapted", "now", now, "entry", id, "next", e.Next)
+	c.record(EventScheduled, id, now, e.Next)
+	c.wakeUp()
+}
@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAdaptiveJob verifies that the cadence follows the durations returned by the job
+func TestAdaptiveJob(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	gaps := []time.Duration{30 * time.Minute, 20 * time.Minute, 10 * time.Minute, 0}
+	ran := make(chan time.Time, len(gaps))
+	i := 0
+	id := c.AddAdaptiveJob(Every(time.Hour), AdaptiveFuncJob(func() (time.Duration, error) {
+		ran <- clk.Now()
+		gap := gaps[i]
+		i++
+		return gap, nil
+	}))
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+	last := <-ran
+	for _, gap := range gaps {
+		c.WaitJobs()
+		e, _ := c.Entry(id)
+		want := gap
+		if want == 0 {
+			want = time.Hour
+		}
+		if got := e.Next.Sub(last); got != want {
+			t.Fatalf("expected next fire %v after the previous one, got %v", want, got)
+		}
+		if gap == 0 {
+			break
+		}
+		clk.BlockUntil(1)
+		clk.Set(e.Next)
+		last = <-ran
+	}
+}
@@ -0,0 +1,24 @@
+package cron
+
+// defaultArchiveLimit 是归档默认保留的最大任务数
+const defaultArchiveLimit = 100
+
+// archiveEntry 将被删除的任务副本加入归档，超出上限时丢弃最旧的记录
+// 调用者需要持有entriesMu
+func (c *Cron) archiveEntry(e *Entry) {
+	archived := *e
+	archived.Removed = c.now()
+	c.archived = append(c.archived, archived)
+	if over := len(c.archived) - c.archiveLimit; over > 0 {
+		c.archived = append(c.archived[:0:0], c.archived[over:]...)
+	}
+}
+
+// Archived 返回被删除任务的归档，按删除时间升序排列
+// 每个任务的Removed字段记录了删除时间
+// 需要通过WithArchiveRemoved启用，未启用时返回空切片
+func (c *Cron) Archived() []Entry {
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	return append([]Entry(nil), c.archived...)
+}
@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestArchiveRemoved verifies that removed entries move into the archive with a removal time
+func TestArchiveRemoved(t *testing.T) {
+	c := New(WithArchiveRemoved())
+	a := c.AddFunc(&TestSchedule{}, func() {})
+	b := c.AddFunc(&TestSchedule{}, func() {})
+	kept := c.AddFunc(&TestSchedule{}, func() {})
+	c.Start()
+
+	before := time.Now()
+	c.Remove(a)
+	c.Remove(b)
+	// the run loop applies removals before it handles the stop signal
+	c.Stop()
+
+	archived := c.Archived()
+	if len(archived) != 2 || archived[0].ID != a || archived[1].ID != b {
+		t.Fatalf("expected entries %d and %d in the archive, got %v", a, b, archived)
+	}
+	for _, e := range archived {
+		if e.Removed.Before(before) {
+			t.Errorf("entry %d: expected removal time after %v, got %v", e.ID, before, e.Removed)
+		}
+	}
+	entries := c.Entries()
+	if len(entries) != 1 || entries[0].ID != kept {
+		t.Errorf("expected only entry %d to remain, got %v", kept, entries)
+	}
+}
+
+// TestArchiveLimit verifies that the archive keeps only the most recently removed entries
+func TestArchiveLimit(t *testing.T) {
+	c := New(WithArchiveRemoved(), WithArchiveLimit(2))
+	var ids []EntryID
+	for i := 0; i < 4; i++ {
+		ids = append(ids, c.AddFunc(&TestSchedule{}, func() {}))
+	}
+	for _, id := range ids {
+		c.Remove(id)
+	}
+
+	archived := c.Archived()
+	if len(archived) != 2 || archived[0].ID != ids[2] || archived[1].ID != ids[3] {
+		t.Errorf("expected the last two removed entries, got %v", archived)
+	}
+
+	plain := New()
+	id := plain.AddFunc(&TestSchedule{}, func() {})
+	plain.Remove(id)
+	if got := plain.Archived(); len(got) != 0 {
+		t.Errorf("expected no archive without WithArchiveRemoved, got %v", got)
+	}
+}
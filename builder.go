@@ -0,0 +1,115 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// builderReference 是Build检查调度能否触发时使用的起始时间
+var builderReference = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Builder 以类型安全的方式逐个字段构造cron调度器，避免拼接和解析表达式字符串
+// 例如: Builder{}.Minutes(0, 30).Hours(9).DaysOfWeek(time.Monday).Build()
+// 等同于"0,30 9 * * 1"，即每周一9:00和9:30触发
+// 与cron表达式相同，未设置的字段表示任意值，但秒未设置时只在第0秒触发；
+// 同一字段多次调用时取值合并。Builder是值类型，每个方法返回修改后的副本，可以安全地复用中间结果
+type Builder struct {
+	seconds, minutes, hours, days []int
+	months                        []time.Month
+	weekdays                      []time.Weekday
+}
+
+// Seconds 限定在这些秒触发，取值范围0-59
+func (b Builder) Seconds(values ...int) Builder {
+	b.seconds = append(slices.Clip(b.seconds), values...)
+	return b
+}
+
+// Minutes 限定在这些分钟触发，取值范围0-59
+func (b Builder) Minutes(values ...int) Builder {
+	b.minutes = append(slices.Clip(b.minutes), values...)
+	return b
+}
+
+// Hours 限定在这些小时触发，取值范围0-23
+func (b Builder) Hours(values ...int) Builder {
+	b.hours = append(slices.Clip(b.hours), values...)
+	return b
+}
+
+// DaysOfMonth 限定在每月的这些日期触发，取值范围1-31
+// 与DaysOfWeek同时设置时按cron惯例满足其一即可
+func (b Builder) DaysOfMonth(values ...int) Builder {
+	b.days = append(slices.Clip(b.days), values...)
+	return b
+}
+
+// Months 限定在这些月份触发
+func (b Builder) Months(values ...time.Month) Builder {
+	b.months = append(slices.Clip(b.months), values...)
+	return b
+}
+
+// DaysOfWeek 限定在星期几触发
+func (b Builder) DaysOfWeek(values ...time.Weekday) Builder {
+	b.weekdays = append(slices.Clip(b.weekdays), values...)
+	return b
+}
+
+// Build 检查所有取值并构造SpecSchedule
+// 取值越界时返回错误；组合起来永远不会触发(如2月30日)时同样返回错误
+func (b Builder) Build() (Schedule, error) {
+	s := &SpecSchedule{}
+	var err error
+	seconds := b.seconds
+	if len(seconds) == 0 {
+		seconds = []int{0}
+	}
+	if s.Second, err = builderField(seconds, secondBounds); err != nil {
+		return nil, err
+	}
+	if s.Minute, err = builderField(b.minutes, minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.Hour, err = builderField(b.hours, hourBounds); err != nil {
+		return nil, err
+	}
+	if s.Dom, err = builderField(b.days, domBounds); err != nil {
+		return nil, err
+	}
+	months := make([]int, len(b.months))
+	for i, m := range b.months {
+		months[i] = int(m)
+	}
+	if s.Month, err = builderField(months, monthBounds); err != nil {
+		return nil, err
+	}
+	weekdays := make([]int, len(b.weekdays))
+	for i, d := range b.weekdays {
+		weekdays[i] = int(d)
+	}
+	if s.Dow, err = builderField(weekdays, dowBounds); err != nil {
+		return nil, err
+	}
+	if s.Next(builderReference).IsZero() {
+		return nil, errors.New("schedule never fires: no date matches the day and month constraints")
+	}
+	return s, nil
+}
+
+// builderField 将取值转换为字段位图，没有取值时返回与"*"相同的位图
+func builderField(values []int, b bounds) (uint64, error) {
+	if len(values) == 0 {
+		return starBits(b), nil
+	}
+	var bits uint64
+	for _, v := range values {
+		if v < int(b.min) || v > int(b.max) {
+			return 0, fmt.Errorf("%s: value %d out of range [%d, %d]", b.name, v, b.min, b.max)
+		}
+		bits |= bitOf(v, b)
+	}
+	return bits, nil
+}
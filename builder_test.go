@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestBuilderMatchesParse verifies that built schedules equal their parsed equivalents
+func TestBuilderMatchesParse(t *testing.T) {
+	tests := []struct {
+		builder Builder
+		spec    string
+	}{
+		{Builder{}.Minutes(0, 30).Hours(9).DaysOfWeek(time.Monday), "0,30 9 * * 1"},
+		{Builder{}.Minutes(15).Hours(2).DaysOfMonth(1, 15).Months(time.January, time.July), "15 2 1,15 1,7 *"},
+		{Builder{}.Minutes(0).Minutes(45), "0,45 * * * *"},
+		{Builder{}, "* * * * *"},
+	}
+	for _, tc := range tests {
+		got, err := tc.builder.Build()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.spec, err)
+			continue
+		}
+		want, _ := Parse(tc.spec)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: expected %+v, got %+v", tc.spec, want, got)
+		}
+	}
+
+	s, err := Builder{}.Seconds(10, 40).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := ParseWithSeconds("10,40 * * * * *")
+	if !reflect.DeepEqual(s, want) {
+		t.Errorf("expected %+v, got %+v", want, s)
+	}
+
+	now := time.Date(2024, 1, 1, 9, 10, 0, 0, time.UTC) // Monday
+	weekly, _ := Builder{}.Minutes(0, 30).Hours(9).DaysOfWeek(time.Monday).Build()
+	if next, want := weekly.Next(now), time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("expected next %v, got %v", want, next)
+	}
+}
+
+// TestBuilderReuse verifies that deriving from a shared builder does not affect other copies
+func TestBuilderReuse(t *testing.T) {
+	base := Builder{}.Minutes(0)
+	a := base.Minutes(10)
+	b := base.Minutes(20)
+	sa, _ := a.Build()
+	sb, _ := b.Build()
+	if sa.(*SpecSchedule).Minute != 1<<0|1<<10 || sb.(*SpecSchedule).Minute != 1<<0|1<<20 {
+		t.Errorf("expected independent minute sets, got %b and %b", sa.(*SpecSchedule).Minute, sb.(*SpecSchedule).Minute)
+	}
+}
+
+// TestBuilderErrors verifies that out-of-range values and impossible dates are rejected
+func TestBuilderErrors(t *testing.T) {
+	tests := []struct {
+		builder Builder
+		want    string
+	}{
+		{Builder{}.Minutes(60), "minute: value 60 out of range"},
+		{Builder{}.Hours(-1), "hour: value -1 out of range"},
+		{Builder{}.DaysOfMonth(0), "day of month: value 0 out of range"},
+		{Builder{}.Months(13), "month: value 13 out of range"},
+		{Builder{}.DaysOfWeek(7), "day of week: value 7 out of range"},
+		{Builder{}.DaysOfMonth(30).Months(time.February), "never fires"},
+	}
+	for _, tc := range tests {
+		if _, err := tc.builder.Build(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("expected error containing %q, got %v", tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package cron
+
+import "time"
+
+// Hourly 创建一个在每小时整点触发的调度器，等同于"0 * * * *"
+func Hourly() Schedule {
+	return &SpecSchedule{
+		Minute: bitOf(0, minuteBounds),
+		Hour:   starBits(hourBounds),
+		Dom:    starBits(domBounds),
+		Month:  starBits(monthBounds),
+		Dow:    starBits(dowBounds),
+	}
+}
+
+// Daily 创建一个每天在hour:min触发的调度器
+// 时刻按调度器配置的时区计算，并正确处理夏令时:
+// 该时刻因夏令时开始而不存在时，当天在切换后的对应时刻触发(例如2:30顺延到3:30)；
+// 该时刻因夏令时结束而出现两次时，当天只触发一次
+// hour或min超出范围时任务不会被触发
+func Daily(hour, min int) Schedule {
+	return calendarSchedule{hour: hour, minute: min, weekday: -1}
+}
+
+// Weekly 创建一个每周在wd的hour:min触发的调度器
+// 夏令时的处理与Daily相同，hour或min超出范围时任务不会被触发
+func Weekly(wd time.Weekday, hour, min int) Schedule {
+	return calendarSchedule{hour: hour, minute: min, weekday: int(wd)}
+}
+
+// Monthly 创建一个每月在第day天的hour:min触发的调度器
+// 没有第day天的月份会被跳过，例如day为31时跳过2月、4月等，在下一个有31日的月份触发
+// 夏令时的处理与Daily相同，day、hour或min超出范围时任务不会被触发
+func Monthly(day, hour, min int) Schedule {
+	if day < 1 {
+		// 0表示不限定日期，无效的日期用-1表示，使调度器不再触发
+		day = -1
+	}
+	return calendarSchedule{hour: hour, minute: min, weekday: -1, day: day}
+}
+
+// calendarSchedule 是每天固定时刻触发、可限定星期或日期的调度器
+// 逐日计算当天的触发时刻，因此每个匹配的日期恰好触发一次，不受夏令时影响
+type calendarSchedule struct {
+	hour, minute int
+	weekday      int // 限定的星期，-1表示不限定
+	day          int // 限定的日期，0表示不限定
+}
+
+// calendarSearchDays 是查找下一次执行时间时向后搜索的最大天数，足以覆盖每月31日等最稀疏的情况
+const calendarSearchDays = 366
+
+// Next 计算下一次执行时间
+// 返回严格晚于t的第一个匹配日期的触发时刻，使用t所在的时区；参数无效时返回零值
+func (s calendarSchedule) Next(t time.Time) time.Time {
+	if s.hour < 0 || s.hour > 23 || s.minute < 0 || s.minute > 59 || s.weekday > 6 || s.day < 0 || s.day > 31 {
+		return time.Time{}
+	}
+	loc := t.Location()
+	year, month, day := t.Date()
+	for i := 0; i <= calendarSearchDays; i++ {
+		// 以正午规范化日期，避免零点附近的夏令时切换影响日期的计算
+		date := time.Date(year, month, day+i, 12, 0, 0, 0, loc)
+		if s.weekday >= 0 && date.Weekday() != time.Weekday(s.weekday) {
+			continue
+		}
+		if s.day > 0 && date.Day() != s.day {
+			continue
+		}
+		// 同一天只产生一个候选，重复的时刻只取time.Date选择的一个，因此不会触发两次
+		at := s.on(date.Year(), date.Month(), date.Day(), loc)
+		if at.After(t) {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// on 返回指定日期的触发时刻
+// 该时刻因夏令时开始而不存在时，time.Date可能将其规范化到切换前的时刻，
+// 这里按切换前的偏移量计算，使其落在切换后的对应时刻，例如2:30变为3:30
+func (s calendarSchedule) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	at := time.Date(year, month, day, s.hour, s.minute, 0, 0, loc)
+	if at.Hour() == s.hour && at.Minute() == s.minute {
+		return at
+	}
+	want := time.Date(year, month, day, s.hour, s.minute, 0, 0, time.UTC)
+	got := time.Date(at.Year(), at.Month(), at.Day(), at.Hour(), at.Minute(), 0, 0, time.UTC)
+	if d := want.Sub(got); d > 0 {
+		return at.Add(d)
+	}
+	// 已经规范化到切换后的时刻
+	return at
+}
+
+// bitOf 返回只允许取值v的字段位图，v超出范围时返回0，字段永远不匹配
+func bitOf(v int, b bounds) uint64 {
+	if v < int(b.min) || v > int(b.max) {
+		return 0
+	}
+	return 1 << uint(v)
+}
+
+// starBits 返回与"*"相同的字段位图
+func starBits(b bounds) uint64 {
+	var bits uint64
+	for v := b.min; v <= b.max; v++ {
+		bits |= 1 << v
+	}
+	return bits | starBit
+}
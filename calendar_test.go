@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCalendarHelpers verifies the next aligned occurrence of each helper
+func TestCalendarHelpers(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		from     string
+		want     string
+	}{
+		{"hourly", Hourly(), "2024-01-01T09:00:00Z", "2024-01-01T10:00:00Z"},
+		{"hourly mid-hour", Hourly(), "2024-01-01T09:30:15Z", "2024-01-01T10:00:00Z"},
+		{"daily later today", Daily(18, 30), "2024-01-01T09:00:00Z", "2024-01-01T18:30:00Z"},
+		{"daily tomorrow", Daily(8, 0), "2024-01-01T09:00:00Z", "2024-01-02T08:00:00Z"},
+		{"weekly", Weekly(time.Friday, 17, 0), "2024-01-01T09:00:00Z", "2024-01-05T17:00:00Z"},
+		{"weekly same day passed", Weekly(time.Monday, 9, 0), "2024-01-01T09:00:00Z", "2024-01-08T09:00:00Z"},
+		{"monthly", Monthly(15, 12, 0), "2024-01-20T00:00:00Z", "2024-02-15T12:00:00Z"},
+		{"monthly 31st skips short months", Monthly(31, 0, 0), "2024-01-31T12:00:00Z", "2024-03-31T00:00:00Z"},
+		{"monthly 30th skips february", Monthly(30, 0, 0), "2024-01-30T12:00:00Z", "2024-03-30T00:00:00Z"},
+		{"monthly 29th in leap year", Monthly(29, 0, 0), "2024-02-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"monthly 29th in common year", Monthly(29, 0, 0), "2023-02-01T00:00:00Z", "2023-03-29T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		if got := tt.schedule.Next(at(tt.from)); !got.Equal(at(tt.want)) {
+			t.Errorf("%s: expected %s, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestCalendarHelpersDST verifies daily schedules across daylight saving transitions in America/New_York
+func TestCalendarHelpersDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	// 02:30 does not exist on 2024-03-10 and rolls forward to 03:30 EDT
+	next := Daily(2, 30).Next(time.Date(2024, 3, 9, 12, 0, 0, 0, ny))
+	if want := time.Date(2024, 3, 9, 7, 30, 0, 0, time.UTC).Add(24 * time.Hour); !next.Equal(want) {
+		t.Errorf("expected %v on the spring forward day, got %v", want.In(ny), next)
+	}
+	if after := Daily(2, 30).Next(next); !after.Equal(time.Date(2024, 3, 11, 2, 30, 0, 0, ny)) {
+		t.Errorf("expected the regular time the day after, got %v", after)
+	}
+
+	// a job at 09:00 stays at 09:00 local time on both sides of the change
+	next = Daily(9, 0).Next(time.Date(2024, 3, 9, 12, 0, 0, 0, ny))
+	if next.Hour() != 9 || next.Day() != 10 {
+		t.Errorf("expected 09:00 local on the transition day, got %v", next)
+	}
+	if gap := next.Sub(time.Date(2024, 3, 9, 9, 0, 0, 0, ny)); gap != 23*time.Hour {
+		t.Errorf("expected a 23 hour gap across spring forward, got %v", gap)
+	}
+
+	// 01:30 happens twice on 2024-11-03 but fires only once that day
+	var fires []time.Time
+	for at := time.Date(2024, 11, 2, 12, 0, 0, 0, ny); len(fires) < 3; {
+		at = Daily(1, 30).Next(at)
+		fires = append(fires, at)
+	}
+	for i, want := range []int{4, 5} {
+		if d := fires[i+1].Day(); d != want {
+			t.Errorf("expected fire %d on November %d, got %v", i+2, want, fires[i+1])
+		}
+	}
+	if fires[0].Day() != 3 || fires[0].Hour() != 1 || fires[0].Minute() != 30 {
+		t.Errorf("expected the first fire at 01:30 on November 3, got %v", fires[0])
+	}
+
+	// the same holds for weekly schedules landing on a transition day
+	next = Weekly(time.Sunday, 2, 30).Next(time.Date(2024, 3, 4, 0, 0, 0, 0, ny))
+	if next.Day() != 10 || next.Hour() != 3 || next.Minute() != 30 {
+		t.Errorf("expected a weekly run rolled forward to 03:30 on March 10, got %v", next)
+	}
+}
+
+// TestCalendarHelpersInvalid verifies that out-of-range arguments never fire
+func TestCalendarHelpersInvalid(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for name, s := range map[string]Schedule{
+		"hour":   Daily(24, 0),
+		"minute": Weekly(time.Monday, 0, 60),
+		"day":    Monthly(32, 0, 0),
+		"zero":   Monthly(0, 0, 0),
+	} {
+		if next := s.Next(from); !next.IsZero() {
+			t.Errorf("%s: expected zero time for invalid arguments, got %v", name, next)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cron
+
+import "time"
+
+// MissedPolicy 定义进程停顿(如休眠、长时间GC)导致任务错过多次执行时的处理策略
+type MissedPolicy int
+
+const (
+	// SkipMissed 只执行一次，之后从当前时间重新计算下次执行时间，是默认策略
+	SkipMissed MissedPolicy = iota
+	// CatchUp 为每次错过的计划时间各执行一次，之后从当前时间重新计算下次执行时间
+	CatchUp
+)
+
+// dueRuns 返回任务本次醒来时需要执行的各次计划时间
+// SkipMissed策略下只返回e.Next，即长时间停顿后也只执行一次
+// CatchUp策略下从e.Next开始依次计算到now为止错过的所有计划时间，
+// 设置了WithCatchUpLimit时丢弃早于now-catchUpWithin的部分，并只保留最近的catchUpMax次
+// 调用者需要持有entriesMu
+func (c *Cron) dueRuns(e *Entry, now time.Time) []time.Time {
+	if c.missedPolicy != CatchUp {
+		return []time.Time{e.Next}
+	}
+	var oldest time.Time
+	if c.catchUpWithin > 0 {
+		oldest = now.Add(-c.catchUpWithin)
+	}
+	var runs []time.Time
+	for t := e.Next; !t.IsZero() && !t.After(now); {
+		if !t.Before(oldest) {
+			runs = append(runs, t)
+			if c.catchUpMax > 0 && len(runs) > c.catchUpMax {
+				runs = runs[1:]
+			}
+		}
+		next := c.scheduleNext(e, t)
+		if !next.After(t) {
+			// 调度器没有向前推进，避免死循环
+			break
+		}
+		t = next
+	}
+	return runs
+}
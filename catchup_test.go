@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCatchUpLimit verifies that only the most recent misses within the window are caught up
+func TestCatchUpLimit(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC), WithCatchUpLimit(3, 10*time.Minute))
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+
+	clk.BlockUntil(1)
+	clk.Advance(30 * time.Minute)
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	if got := runs.Load(); got != 3 {
+		t.Errorf("expected 3 catch-up runs, got %d", got)
+	}
+	e, _ := c.Entry(id)
+	if want := start.Add(30 * time.Minute); !e.Prev.Equal(want) {
+		t.Errorf("expected prev %v, got %v", want, e.Prev)
+	}
+}
+
+// TestCatchUpDisabled verifies that a long stall fires a job only once by default
+func TestCatchUpDisabled(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	var runs atomic.Int32
+	c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+
+	clk.BlockUntil(1)
+	clk.Advance(30 * time.Minute)
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	if got := runs.Load(); got != 1 {
+		t.Errorf("expected 1 run, got %d", got)
+	}
+}
+
+// TestCatchUpWindowExpired verifies that misses older than the window are dropped
+func TestCatchUpWindowExpired(t *testing.T) {
+	c := New(WithCatchUpLimit(3, time.Minute))
+	now := time.Date(2024, 1, 1, 1, 5, 0, 0, time.UTC)
+	e := &Entry{Schedule: Every(time.Hour), Next: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if runs := c.dueRuns(e, now); len(runs) != 0 {
+		t.Errorf("expected no runs, got %v", runs)
+	}
+}
+
+// TestMissedPolicyCatchUp verifies that every missed interval fires once after a long gap
+func TestMissedPolicyCatchUp(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithMissedPolicy(CatchUp))
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+
+	clk.BlockUntil(1)
+	clk.Advance(30 * time.Minute)
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	if got := runs.Load(); got != 30 {
+		t.Errorf("expected 30 catch-up runs, got %d", got)
+	}
+	e, _ := c.Entry(id)
+	if want := start.Add(31 * time.Minute); !e.Next.Equal(want) {
+		t.Errorf("expected next to realign to %v, got %v", want, e.Next)
+	}
+}
+
+// TestMissedPolicySkip verifies that SkipMissed fires once and realigns to now
+func TestMissedPolicySkip(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithMissedPolicy(SkipMissed))
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+
+	clk.BlockUntil(1)
+	clk.Advance(30*time.Minute + 30*time.Second)
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	if got := runs.Load(); got != 1 {
+		t.Errorf("expected 1 run, got %d", got)
+	}
+	e, _ := c.Entry(id)
+	if want := start.Add(31*time.Minute + 30*time.Second); !e.Next.Equal(want) {
+		t.Errorf("expected next to realign to %v, got %v", want, e.Next)
+	}
+	if err := WithMissedPolicy(MissedPolicy(42))(&Cron{}); err == nil {
+		t.Error("expected error for unknown missed policy")
+	}
+}
@@ -0,0 +1,337 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// JobWrapper 用于包装任务，在任务执行前后附加额外行为
+// 例如串行化、跳过重叠执行等
+type JobWrapper func(Job) Job
+
+// Chain 是一组按顺序组合的JobWrapper
+// 使用示例:
+//
+//	job := cron.NewChain(cron.DelayIfStillRunning(logger)).Then(job)
+type Chain struct {
+	wrappers []JobWrapper
+}
+
+// NewChain 创建一个由给定JobWrapper组成的Chain
+func NewChain(c ...JobWrapper) Chain {
+	return Chain{wrappers: c}
+}
+
+// Then 使用Chain中的所有JobWrapper包装任务并返回
+// 包装顺序为: NewChain(m1, m2, m3).Then(job) 等价于 m1(m2(m3(job)))
+func (c Chain) Then(j Job) Job {
+	for i := range c.wrappers {
+		j = c.wrappers[len(c.wrappers)-i-1](j)
+	}
+	return j
+}
+
+// DelayIfStillRunning 串行化同一任务的执行
+// 如果上一次执行尚未结束，本次触发会进入队列，等上一次结束后依次执行
+// 积压的触发可以通过Cron.DrainBacklog以更高的并发度尽快执行完
+func DelayIfStillRunning(logger Logger) JobWrapper {
+	return func(j Job) Job {
+		return &delayJob{job: j, logger: logger}
+	}
+}
+
+// delayJob 是DelayIfStillRunning返回的任务，维护一个待执行触发的队列
+type delayJob struct {
+	job     Job
+	logger  Logger
+	mu      sync.Mutex
+	running bool        // 是否有执行中的实例
+	queue   []time.Time // 排队中的触发时间
+}
+
+// Run 实现Job接口
+func (j *delayJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 没有执行中的实例时直接执行，并在结束后依次执行队列中的触发
+// 排队的触发与本次执行共用ctx，返回本次执行的错误，排队执行的错误只记录日志
+func (j *delayJob) runContext(ctx context.Context) error {
+	j.mu.Lock()
+	if j.running {
+		j.queue = append(j.queue, time.Now())
+		j.mu.Unlock()
+		return nil
+	}
+	j.running = true
+	j.mu.Unlock()
+
+	finished := false
+	defer func() {
+		// 任务panic时释放执行状态，避免后续触发全部堆积在队列中
+		if !finished {
+			j.mu.Lock()
+			j.running = false
+			j.mu.Unlock()
+		}
+	}()
+
+	err := invoke(ctx, j.job)
+	for {
+		j.mu.Lock()
+		if len(j.queue) == 0 {
+			j.running = false
+			j.mu.Unlock()
+			finished = true
+			return err
+		}
+		queued := j.queue[0]
+		j.queue = j.queue[1:]
+		j.mu.Unlock()
+		j.logger.Info("delay", "duration", time.Since(queued))
+
+		if qerr := invoke(ctx, j.job); qerr != nil {
+			j.logger.Error("delayed run failed", "error", qerr)
+		}
+	}
+}
+
+// takeBacklog 取出队列中所有待执行的触发，返回其数量
+func (j *delayJob) takeBacklog() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	n := len(j.queue)
+	j.queue = nil
+	return n
+}
+
+// SkipIfStillRunning 跳过与上一次执行重叠的触发
+// 如果同一任务的上一次执行尚未结束，本次触发直接丢弃并记录reason为"still-running"的跳过日志
+// 执行状态只属于被包装的任务，不同任务之间互不影响
+func SkipIfStillRunning(logger Logger) JobWrapper {
+	return func(j Job) Job {
+		return &skipJob{job: j, logger: logger}
+	}
+}
+
+// skipJob 是SkipIfStillRunning返回的任务
+type skipJob struct {
+	job     Job
+	logger  Logger
+	mu      sync.Mutex
+	running bool // 是否有执行中的实例
+}
+
+// Run 实现Job接口
+func (j *skipJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 没有执行中的实例时执行任务，否则记录日志并跳过
+func (j *skipJob) runContext(ctx context.Context) error {
+	j.mu.Lock()
+	if j.running {
+		j.mu.Unlock()
+		j.logger.Info("skip", "now", time.Now(), "reason", "still-running")
+		return nil
+	}
+	j.running = true
+	j.mu.Unlock()
+
+	// 任务panic时同样释放执行状态
+	defer func() {
+		j.mu.Lock()
+		j.running = false
+		j.mu.Unlock()
+	}()
+	return invoke(ctx, j.job)
+}
+
+// Sample 按概率执行任务，每次触发以1-probability的概率跳过本次执行，适合不需要每次都执行的高开销诊断任务
+// 随机数取自执行任务的调度器的随机数源，测试中可通过WithRand注入固定种子的随机源；
+// 跳过时通过调度器的日志记录reason为"sampled-out"的跳过日志
+// 直接调用Job.Run而不经过调度器时使用全局随机源，跳过时不记录日志
+// probability小于0或为NaN时按0处理(从不执行)，大于1时按1处理(总是执行)
+func Sample(probability float64) JobWrapper {
+	if probability != probability || probability < 0 {
+		probability = 0
+	}
+	probability = min(probability, 1)
+	return func(j Job) Job {
+		return &sampleJob{job: j, probability: probability}
+	}
+}
+
+// sampleJob 是Sample返回的任务
+type sampleJob struct {
+	job         Job
+	probability float64
+}
+
+// Run 实现Job接口
+func (j *sampleJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 抽样命中时执行任务，否则记录日志并跳过
+func (j *sampleJob) runContext(ctx context.Context) error {
+	c := cronFrom(ctx)
+	if c == nil {
+		if randOrDefault(nil).Float64() < j.probability {
+			return invoke(ctx, j.job)
+		}
+		return nil
+	}
+	if c.randFloat() >= j.probability {
+		id, now := entryIDFrom(ctx), c.now()
+		c.logger.Info("skip", "now", now, "entry", id, "reason", "sampled-out")
+		c.record(EventSkipped, id, now, time.Time{})
+		return nil
+	}
+	return invoke(ctx, j.job)
+}
+
+// WithCooldown 保证同一任务两次实际启动之间至少间隔d
+// 距离上一次启动不足d时丢弃本次启动并记录reason为"cooldown"的跳过日志，
+// 无论触发来自调度还是RunNow，用于防止意外的重复触发
+func WithCooldown(d time.Duration, logger Logger) JobWrapper {
+	return func(j Job) Job {
+		return &cooldownJob{job: j, cooldown: d, logger: logger}
+	}
+}
+
+// cooldownJob 是WithCooldown返回的任务
+type cooldownJob struct {
+	job       Job
+	cooldown  time.Duration
+	logger    Logger
+	mu        sync.Mutex
+	lastStart time.Time // 上一次实际启动的时间
+}
+
+// Run 实现Job接口
+func (j *cooldownJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 距离上一次启动超过冷却时间时执行任务，否则跳过
+func (j *cooldownJob) runContext(ctx context.Context) error {
+	now := time.Now()
+	j.mu.Lock()
+	if !j.lastStart.IsZero() && now.Sub(j.lastStart) < j.cooldown {
+		last := j.lastStart
+		j.mu.Unlock()
+		j.logger.Info("skip", "now", now, "reason", "cooldown", "last", last)
+		return nil
+	}
+	j.lastStart = now
+	j.mu.Unlock()
+	return invoke(ctx, j.job)
+}
+
+// Retry 在任务返回错误时重试，最多执行attempts次，成功后不再重试
+// 第一次重试前等待backoff，之后每次等待时间加倍，每次重试都会记录日志
+// 只有ErrorJob等能返回错误的任务会被重试，普通Job总是视为成功；任务panic时不会重试
+// 等待期间任务的context被取消时停止重试，返回最后一次的错误
+// attempts小于1时视为1
+func Retry(attempts int, backoff time.Duration, logger Logger) JobWrapper {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return func(j Job) Job {
+		return &retryJob{job: j, attempts: attempts, backoff: backoff, logger: logger}
+	}
+}
+
+// retryJob 是Retry返回的任务
+type retryJob struct {
+	job      Job
+	attempts int
+	backoff  time.Duration
+	logger   Logger
+}
+
+// Run 实现Job接口
+func (j *retryJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 执行任务，失败时按退避时间重试
+func (j *retryJob) runContext(ctx context.Context) error {
+	wait := j.backoff
+	err := invoke(ctx, j.job)
+	for attempt := 2; err != nil && attempt <= j.attempts; attempt++ {
+		j.logger.Info("retry", "attempt", attempt, "backoff", wait, "error", err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		wait *= 2
+		err = invoke(ctx, j.job)
+	}
+	return err
+}
+
+// WithTimeout 限制任务单次执行的时长
+// 任务收到的ctx在d之后取消，支持context的任务应据此尽快返回；
+// 到达时限时记录Error日志并立即返回context.DeadlineExceeded，本次执行视为失败
+// 无法强制终止不响应取消的任务，它会在后台继续执行到结束，且不再计入Stop等待的任务
+func WithTimeout(d time.Duration, logger Logger) JobWrapper {
+	return func(j Job) Job {
+		return &timeoutJob{job: j, timeout: d, logger: logger}
+	}
+}
+
+// timeoutJob 是WithTimeout返回的任务
+type timeoutJob struct {
+	job     Job
+	timeout time.Duration
+	logger  Logger
+}
+
+// timeoutResult 是timeoutJob中一次执行的结果
+type timeoutResult struct {
+	err       error
+	panicked  bool
+	recovered any
+}
+
+// Run 实现Job接口
+func (j *timeoutJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 在新的goroutine中执行任务，等待其结束或到达时限
+// 任务在时限内panic时在调用者的goroutine中重新panic，以便调度器按panic策略处理
+func (j *timeoutJob) runContext(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	done := make(chan timeoutResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- timeoutResult{panicked: true, recovered: r}
+			}
+		}()
+		done <- timeoutResult{err: invoke(ctx, j.job)}
+	}()
+
+	select {
+	case r := <-done:
+		if r.panicked {
+			panic(r.recovered)
+		}
+		return r.err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			j.logger.Error("job timed out", "timeout", j.timeout)
+		}
+		return ctx.Err()
+	}
+}
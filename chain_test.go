@@ -0,0 +1,287 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestSample verifies that a sampled job runs roughly the expected fraction of ticks using the scheduler's random source
+func TestSample(t *testing.T) {
+	sampled := func() ([]bool, *recordingLogger) {
+		logger := &recordingLogger{}
+		c := New(WithRand(rand.New(rand.NewPCG(1, 2))), WithLogger(logger))
+		var ran bool
+		job := NewChain(Sample(0.1)).Then(FuncJob(func() { ran = true }))
+		id := c.AddJob(Every(time.Hour), job)
+		hits := make([]bool, 10000)
+		for i := range hits {
+			ran = false
+			_ = c.runJob(context.Background(), id, job)
+			hits[i] = ran
+		}
+		return hits, logger
+	}
+
+	hits, logger := sampled()
+	var runs int
+	for _, hit := range hits {
+		if hit {
+			runs++
+		}
+	}
+	if runs < 900 || runs > 1100 {
+		t.Errorf("expected about 1000 runs out of %d, got %d", len(hits), runs)
+	}
+	var skips int
+	for _, msg := range logger.messages {
+		if msg == "info skip" {
+			skips++
+		}
+	}
+	if skips != len(hits)-runs {
+		t.Errorf("expected %d logged skips, got %d", len(hits)-runs, skips)
+	}
+	if again, _ := sampled(); !slices.Equal(hits, again) {
+		t.Error("expected the same seed to sample the same runs")
+	}
+}
+
+// TestSampleBounds verifies that probabilities are clamped so that 0 never runs and 1 always runs
+func TestSampleBounds(t *testing.T) {
+	for _, tc := range []struct {
+		probability float64
+		want        int
+	}{
+		{0, 0},
+		{-0.1, 0},
+		{math.NaN(), 0},
+		{1, 100},
+		{1.5, 100},
+	} {
+		var runs int
+		job := Sample(tc.probability)(FuncJob(func() { runs++ }))
+		for range 100 {
+			job.Run()
+		}
+		if runs != tc.want {
+			t.Errorf("probability %v: expected %d runs, got %d", tc.probability, tc.want, runs)
+		}
+	}
+}
+
+// TestWithCooldown verifies that starts within the cooldown are dropped
+func TestWithCooldown(t *testing.T) {
+	c := New()
+	var runs atomic.Int32
+	job := NewChain(WithCooldown(100*time.Millisecond, &discardLogger{})).Then(FuncJob(func() { runs.Add(1) }))
+	id := c.AddJob(&TestSchedule{}, job)
+
+	c.RunNow(id)
+	time.Sleep(20 * time.Millisecond)
+	c.RunNow(id)
+	time.Sleep(20 * time.Millisecond)
+	if got := runs.Load(); got != 1 {
+		t.Errorf("expected 1 run within the cooldown, got %d", got)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	c.RunNow(id)
+	<-c.Stop().Done()
+	if got := runs.Load(); got != 2 {
+		t.Errorf("expected a second run after the cooldown, got %d", got)
+	}
+}
+
+// TestSkipIfStillRunning verifies that a slow job scheduled faster than it completes never overlaps
+func TestSkipIfStillRunning(t *testing.T) {
+	var running, overlaps, runs atomic.Int32
+	slow := FuncJob(func() {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(200 * time.Millisecond)
+		running.Add(-1)
+		runs.Add(1)
+	})
+	c := New()
+	c.AddJob(Every(50*time.Millisecond), NewChain(SkipIfStillRunning(&discardLogger{})).Then(slow))
+	c.Start()
+	time.Sleep(500 * time.Millisecond)
+	<-c.Stop().Done()
+
+	if n := overlaps.Load(); n != 0 {
+		t.Errorf("expected no overlapping runs, got %d", n)
+	}
+	if n := runs.Load(); n < 1 || n > 3 {
+		t.Errorf("expected 1 to 3 completed runs, got %d", n)
+	}
+}
+
+// TestSkipIfStillRunningPerJob verifies that a running job does not block an unrelated wrapped job
+func TestSkipIfStillRunningPerJob(t *testing.T) {
+	wrapper := SkipIfStillRunning(&discardLogger{})
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocked := wrapper(FuncJob(func() {
+		close(started)
+		<-release
+	}))
+	var ran bool
+	other := wrapper(FuncJob(func() { ran = true }))
+
+	go blocked.Run()
+	<-started
+	other.Run()
+	close(release)
+	if !ran {
+		t.Error("expected an unrelated job to run while another is still running")
+	}
+}
+
+// TestDelayIfStillRunning verifies that overlapping ticks of a slow job run back-to-back and none are dropped
+func TestDelayIfStillRunning(t *testing.T) {
+	var triggers, running, overlaps, completed atomic.Int32
+	slow := FuncJob(func() {
+		if running.Add(1) > 1 {
+			overlaps.Add(1)
+		}
+		time.Sleep(60 * time.Millisecond)
+		running.Add(-1)
+		completed.Add(1)
+	})
+	logger := &recordingLogger{}
+	delayed := NewChain(DelayIfStillRunning(logger)).Then(slow)
+	c := New()
+	c.AddFunc(Every(20*time.Millisecond), func() {
+		triggers.Add(1)
+		delayed.Run()
+	})
+	c.Start()
+	time.Sleep(200 * time.Millisecond)
+	<-c.Stop().Done()
+
+	if n := overlaps.Load(); n != 0 {
+		t.Errorf("expected no overlapping runs, got %d", n)
+	}
+	if got, want := completed.Load(), triggers.Load(); got != want || want < 2 {
+		t.Errorf("expected every one of %d triggers to run, got %d", want, got)
+	}
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	if len(logger.messages) == 0 || logger.messages[0] != "info delay" {
+		t.Errorf("expected delayed runs to be logged, got %v", logger.messages)
+	}
+}
+
+// TestRetry verifies that a job failing twice is retried until it succeeds
+func TestRetry(t *testing.T) {
+	var calls int
+	logger := &recordingLogger{}
+	job := Retry(5, time.Millisecond, logger)(errorJob{job: ErrorFuncJob(func() error {
+		if calls++; calls < 3 {
+			return errors.New("flaky")
+		}
+		return nil
+	})})
+
+	if err := invoke(context.Background(), job); err != nil {
+		t.Errorf("expected success after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 invocations, got %d", calls)
+	}
+	if n := len(logger.messages); n != 2 {
+		t.Errorf("expected 2 logged retries, got %v", logger.messages)
+	}
+}
+
+// TestRetryExhausted verifies that the last error is returned once all attempts fail
+func TestRetryExhausted(t *testing.T) {
+	var calls int
+	errFail := errors.New("fail")
+	job := Retry(3, time.Millisecond, &discardLogger{})(errorJob{job: ErrorFuncJob(func() error {
+		calls++
+		return errFail
+	})})
+
+	start := time.Now()
+	if err := invoke(context.Background(), job); !errors.Is(err, errFail) {
+		t.Errorf("expected %v, got %v", errFail, err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 invocations, got %d", calls)
+	}
+	// backoff doubles: 1ms then 2ms
+	if elapsed := time.Since(start); elapsed < 3*time.Millisecond {
+		t.Errorf("expected at least 3ms of backoff, got %v", elapsed)
+	}
+}
+
+// TestRetryCancelled verifies that a cancelled context stops further retries
+func TestRetryCancelled(t *testing.T) {
+	var calls int
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	job := Retry(3, time.Hour, &discardLogger{})(errorJob{job: ErrorFuncJob(func() error {
+		calls++
+		return errors.New("fail")
+	})})
+	if err := invoke(ctx, job); err == nil {
+		t.Error("expected the error to be returned")
+	}
+	if calls != 1 {
+		t.Errorf("expected no retries after cancellation, got %d invocations", calls)
+	}
+}
+
+// TestWithTimeout verifies that fast jobs finish normally while slow ones are cut off at the deadline
+func TestWithTimeout(t *testing.T) {
+	wrapper := WithTimeout(50*time.Millisecond, &discardLogger{})
+
+	fast := wrapper(errorJob{job: ErrorFuncJob(func() error { return nil })})
+	if err := invoke(context.Background(), fast); err != nil {
+		t.Errorf("expected fast job to succeed, got %v", err)
+	}
+
+	cancelled := make(chan struct{})
+	cooperative := wrapper(contextJob{job: ContextFuncJob(func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+	})})
+	if err := invoke(context.Background(), cooperative); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded for a slow context job, got %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the context job to observe cancellation")
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	stubborn := wrapper(FuncJob(func() { <-release }))
+	start := time.Now()
+	if err := invoke(context.Background(), stubborn); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded for a slow plain job, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected to return at the deadline, took %v", elapsed)
+	}
+}
+
+// TestWithTimeoutPanic verifies that a panic inside the time limit reaches the caller
+func TestWithTimeoutPanic(t *testing.T) {
+	job := WithTimeout(time.Second, &discardLogger{})(FuncJob(func() { panic("boom") }))
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("expected panic %q to propagate, got %v", "boom", r)
+		}
+	}()
+	job.Run()
+}
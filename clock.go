@@ -0,0 +1,142 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock 定义了调度器获取当前时间和创建定时器的接口
+// 默认使用系统时钟，测试中可以通过WithClock注入FakeClock以精确控制时间
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer 定义了Clock创建的定时器的接口，与*time.Timer的用法相同
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// realClock 使用系统时钟实现Clock接口
+type realClock struct{}
+
+// Now 实现Clock接口
+func (realClock) Now() time.Time { return time.Now() }
+
+// NewTimer 实现Clock接口
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+// realTimer 将*time.Timer适配为Timer接口
+type realTimer struct {
+	t *time.Timer
+}
+
+// C 实现Timer接口
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+
+// Stop 实现Timer接口
+func (t realTimer) Stop() bool { return t.t.Stop() }
+
+// FakeClock 是手动推进的Clock实现，用于测试
+// 时间只会在调用Advance或Set时变化，到期的定时器随之触发
+// 可并发使用
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFakeClock 创建一个当前时间为now的FakeClock
+func NewFakeClock(now time.Time) *FakeClock {
+	f := &FakeClock{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now 实现Clock接口，返回当前的模拟时间
+func (f *FakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer 实现Clock接口
+// d小于等于0时定时器立即触发
+func (f *FakeClock) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTimer{clock: f, when: f.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- f.now
+		return t
+	}
+	f.timers = append(f.timers, t)
+	f.cond.Broadcast()
+	return t
+}
+
+// Advance 将模拟时间向前推进d，并触发所有到期的定时器
+func (f *FakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(f.now.Add(d))
+}
+
+// Set 将模拟时间设置为t，并触发所有到期的定时器
+func (f *FakeClock) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(t)
+}
+
+// BlockUntil 阻塞直到至少有n个尚未触发的定时器
+// 用于等待调度器的主循环进入等待状态后再推进时间
+func (f *FakeClock) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+// set 更新模拟时间并触发到期的定时器，调用者需要持有mu
+func (f *FakeClock) set(t time.Time) {
+	f.now = t
+	pending := f.timers[:0]
+	for _, timer := range f.timers {
+		if timer.when.After(t) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- t
+	}
+	f.timers = pending
+}
+
+// stop 删除尚未触发的定时器，返回定时器是否处于等待状态
+func (f *FakeClock) stop(t *fakeTimer) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, timer := range f.timers {
+		if timer == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer 是FakeClock创建的定时器
+type fakeTimer struct {
+	clock *FakeClock
+	when  time.Time
+	c     chan time.Time
+}
+
+// C 实现Timer接口
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+// Stop 实现Timer接口
+func (t *fakeTimer) Stop() bool { return t.clock.stop(t) }
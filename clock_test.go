@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFakeClock verifies that timers fire only when the fake time reaches their deadline
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	timer := clk.NewTimer(time.Minute)
+	stopped := clk.NewTimer(time.Minute)
+	if !stopped.Stop() {
+		t.Error("expected stop to report a pending timer")
+	}
+
+	clk.Advance(30 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+
+	clk.Advance(30 * time.Second)
+	select {
+	case got := <-timer.C():
+		if want := start.Add(time.Minute); !got.Equal(want) {
+			t.Errorf("expected fire time %v, got %v", want, got)
+		}
+	default:
+		t.Fatal("timer did not fire at its deadline")
+	}
+	select {
+	case <-stopped.C():
+		t.Error("stopped timer fired")
+	default:
+	}
+
+	immediate := clk.NewTimer(0)
+	select {
+	case <-immediate.C():
+	default:
+		t.Error("expected a non-positive duration to fire immediately")
+	}
+}
+
+// TestRunLoopReleasesTimers verifies that repeated wake cycles do not accumulate pending timers
+func TestRunLoopReleasesTimers(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	c.AddFunc(Every(time.Second), func() {})
+	c.AddFunc(&TestSchedule{}, func() {})
+	c.Start()
+	defer c.Stop()
+
+	for i := range 1000 {
+		clk.BlockUntil(1)
+		if i%2 == 0 {
+			c.WakeNow()
+		} else {
+			clk.Advance(time.Second)
+		}
+	}
+	clk.BlockUntil(1)
+	time.Sleep(10 * time.Millisecond)
+
+	clk.mu.Lock()
+	pending := len(clk.timers)
+	clk.mu.Unlock()
+	if pending != 1 {
+		t.Errorf("expected a single pending timer after many wake cycles, got %d", pending)
+	}
+}
+
+// laggingSchedule runs every minute and advances the fake clock by lag whenever
+// it is consulted after the first call, simulating a slow scheduling pass
+type laggingSchedule struct {
+	clock *FakeClock
+	lag   time.Duration
+	calls int
+}
+
+func (s *laggingSchedule) Next(t time.Time) time.Time {
+	if s.calls++; s.calls > 1 {
+		s.clock.Advance(s.lag)
+	}
+	return t.Add(time.Minute)
+}
+
+// TestTimerUsesCurrentTime verifies that the sleep duration is measured from the current time rather than the previous wake
+func TestTimerUsesCurrentTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	var runs atomic.Int32
+	c.AddFunc(&laggingSchedule{clock: clk, lag: 30 * time.Second}, func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute) // fires at 00:01, the scheduling pass lags until 00:01:30
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if n := runs.Load(); n != 1 {
+		t.Fatalf("expected 1 run after the first minute, got %d", n)
+	}
+
+	clk.Advance(30 * time.Second) // 00:02 is the next scheduled run
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if n := runs.Load(); n != 2 {
+		t.Errorf("expected the job to fire at its scheduled time, got %d runs", n)
+	}
+}
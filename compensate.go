@@ -0,0 +1,52 @@
+package cron
+
+import "context"
+
+// AddFuncWithCompensation 添加一个带有补偿操作的定时任务
+// do返回错误或panic时，在任务goroutine中调用undo撤销do已经完成的部分
+// do的错误照常记录日志，panic在undo执行后继续交给panic策略处理
+// undo自身的panic会被捕获并记录Error日志；do或undo为nil时与AddJob添加nil任务相同，任务不会被添加
+func (c *Cron) AddFuncWithCompensation(schedule Schedule, do func() error, undo func(), opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, &compensationJob{do: do, undo: undo, logger: c.logger}, opts...)
+}
+
+// compensationJob 是AddFuncWithCompensation添加的任务
+type compensationJob struct {
+	do     func() error
+	undo   func()
+	logger Logger
+}
+
+// Run 实现Job接口
+func (j *compensationJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// isNil 实现nilChecker接口，do或undo为nil时任务无法按约定执行
+func (j *compensationJob) isNil() bool {
+	return j.do == nil || j.undo == nil
+}
+
+// runContext 执行do，失败或panic时执行补偿操作
+func (j *compensationJob) runContext(context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			j.compensate()
+			panic(r)
+		}
+	}()
+	if err = j.do(); err != nil {
+		j.compensate()
+	}
+	return err
+}
+
+// compensate 执行补偿操作并捕获其panic
+func (j *compensationJob) compensate() {
+	defer func() {
+		if r := recover(); r != nil {
+			j.logger.Error("compensation panic recovered", "error", r)
+		}
+	}()
+	j.undo()
+}
@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestCompensation verifies that undo runs once per failure or panic and never on success
+func TestCompensation(t *testing.T) {
+	c := New()
+	var fail, panics bool
+	var undos int
+	id := c.AddFuncWithCompensation(&TestSchedule{}, func() error {
+		if panics {
+			panic("boom")
+		}
+		if fail {
+			return errors.New("failed")
+		}
+		return nil
+	}, func() { undos++ })
+	e, _ := c.Entry(id)
+
+	run := func() error { return c.runJob(context.Background(), id, e.Job) }
+	if err := run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if undos != 0 {
+		t.Errorf("expected no compensation on success, got %d", undos)
+	}
+
+	fail = true
+	for i := 1; i <= 2; i++ {
+		if err := run(); err == nil {
+			t.Error("expected error from failing job")
+		}
+		if undos != i {
+			t.Errorf("expected %d compensations, got %d", i, undos)
+		}
+	}
+
+	panics = true
+	if err := run(); err == nil {
+		t.Error("expected error from panicking job")
+	}
+	if undos != 3 {
+		t.Errorf("expected compensation after panic, got %d", undos)
+	}
+}
+
+// TestCompensationPanic verifies that a panicking undo does not escape the job
+func TestCompensationPanic(t *testing.T) {
+	c := New()
+	id := c.AddFuncWithCompensation(&TestSchedule{}, func() error {
+		return errors.New("failed")
+	}, func() { panic("undo failed") })
+	e, _ := c.Entry(id)
+
+	if err := c.runJob(context.Background(), id, e.Job); err == nil || err.Error() != "failed" {
+		t.Errorf("expected the job error, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// acquireSlot 占用一个并发名额，未设置WithMaxConcurrent时总是成功
+// 设置了WithSkipWhenFull且名额已满时记录跳过日志并返回false，否则等待空闲的名额
+func (c *Cron) acquireSlot(id EntryID) bool {
+	if c.slots == nil {
+		return true
+	}
+	if !c.skipWhenFull {
+		c.slots <- struct{}{}
+		return true
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	default:
+		now := c.now()
+		c.logger.Info("skip", "now", now, "entry", id, "reason", "max-concurrent")
+		c.record(EventSkipped, id, now, time.Time{})
+		return false
+	}
+}
+
+// releaseSlot 释放acquireSlot占用的名额
+func (c *Cron) releaseSlot() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// groupLock 返回互斥组name对应的锁，第一次使用时创建
+func (c *Cron) groupLock(name string) *sync.Mutex {
+	c.groupsMu.Lock()
+	defer c.groupsMu.Unlock()
+	if c.groups == nil {
+		c.groups = make(map[string]*sync.Mutex)
+	}
+	mu, ok := c.groups[name]
+	if !ok {
+		mu = &sync.Mutex{}
+		c.groups[name] = mu
+	}
+	return mu
+}
@@ -0,0 +1,184 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMaxConcurrent verifies that a burst of due entries never runs more than n jobs at once and none are lost
+func TestMaxConcurrent(t *testing.T) {
+	c := New(WithMaxConcurrent(3))
+	var current, peak, completed atomic.Int32
+	job := func() {
+		n := current.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		current.Add(-1)
+		completed.Add(1)
+	}
+	var ids []EntryID
+	for range 20 {
+		ids = append(ids, c.AddFunc(&TestSchedule{}, job))
+	}
+	for _, id := range ids {
+		c.RunNow(id)
+	}
+	<-c.Stop().Done()
+
+	if p := peak.Load(); p > 3 {
+		t.Errorf("expected at most 3 concurrent jobs, got %d", p)
+	}
+	if n := completed.Load(); n != 20 {
+		t.Errorf("expected all 20 jobs to wait for a slot and run, got %d", n)
+	}
+}
+
+// TestSkipWhenFull verifies that jobs triggered while all slots are busy are skipped
+func TestSkipWhenFull(t *testing.T) {
+	c := New(WithMaxConcurrent(1), WithSkipWhenFull(), WithEventBuffer(10))
+	release := make(chan struct{})
+	started := make(chan struct{})
+	busy := c.AddFunc(&TestSchedule{}, func() {
+		close(started)
+		<-release
+	})
+	var skipped atomic.Int32
+	other := c.AddFunc(&TestSchedule{}, func() { skipped.Add(1) })
+
+	c.RunNow(busy)
+	<-started
+	c.RunNow(other)
+	for c.IsJobRunning(other) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	<-c.Stop().Done()
+
+	if n := skipped.Load(); n != 0 {
+		t.Errorf("expected the second job to be skipped, ran %d times", n)
+	}
+	var found bool
+	for _, ev := range c.RecentEvents(0) {
+		found = found || (ev.Kind == EventSkipped && ev.EntryID == other)
+	}
+	if !found {
+		t.Error("expected a skipped event for the second job")
+	}
+
+	c.RunNow(other)
+	c.WaitJobs()
+	if n := skipped.Load(); n != 1 {
+		t.Errorf("expected the job to run once a slot is free, got %d", n)
+	}
+}
+
+// TestSkipWhenFullFixedDelay verifies that a fixed-delay entry skipped for lack of a slot is rescheduled
+func TestSkipWhenFullFixedDelay(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithMaxConcurrent(1), WithSkipWhenFull())
+	release := make(chan struct{})
+	started := make(chan struct{})
+	busy := c.AddFunc(&TestSchedule{}, func() {
+		close(started)
+		<-release
+	})
+	var runs atomic.Int32
+	id := c.AddFunc(FixedDelay(time.Minute), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	c.RunNow(busy)
+	<-started
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	want := start.Add(2 * time.Minute)
+	deadline := time.Now().Add(time.Second)
+	for e, _ := c.Entry(id); !e.Next.Equal(want) && time.Now().Before(deadline); e, _ = c.Entry(id) {
+		time.Sleep(time.Millisecond)
+	}
+	if e, _ := c.Entry(id); !e.Next.Equal(want) {
+		t.Fatalf("expected skipped entry to be rescheduled at %v, got %v", want, e.Next)
+	}
+	if n := runs.Load(); n != 0 {
+		t.Errorf("expected the fixed-delay run to be skipped, ran %d times", n)
+	}
+
+	close(release)
+	c.WaitJobs()
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	deadline = time.Now().Add(time.Second)
+	for runs.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected the entry to run once a slot is free, got %d", n)
+	}
+}
+
+// TestMaxConcurrentInvalid verifies that non-positive limits are rejected
+func TestMaxConcurrentInvalid(t *testing.T) {
+	if err := WithMaxConcurrent(0)(&Cron{}); err == nil {
+		t.Error("expected error for zero limit")
+	}
+}
+
+// TestWithGroup verifies that different jobs in the same group never overlap
+func TestWithGroup(t *testing.T) {
+	c := New()
+	var active, maxActive atomic.Int32
+	job := func() {
+		n := active.Add(1)
+		for {
+			m := maxActive.Load()
+			if n <= m || maxActive.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		active.Add(-1)
+	}
+	a := c.AddFunc(Every(time.Hour), job, WithGroup("db"))
+	b := c.AddFunc(Every(time.Hour), job, WithGroup("db"))
+
+	for range 5 {
+		c.RunNow(a)
+		c.RunNow(b)
+	}
+	c.WaitJobs()
+	if n := maxActive.Load(); n != 1 {
+		t.Errorf("expected jobs in the same group to run one at a time, got %d concurrent", n)
+	}
+}
+
+// TestWithGroupIndependent verifies that jobs in different groups still run concurrently
+func TestWithGroupIndependent(t *testing.T) {
+	c := New()
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	job := func() {
+		started <- struct{}{}
+		<-release
+	}
+	a := c.AddFunc(Every(time.Hour), job, WithGroup("db"))
+	b := c.AddFunc(Every(time.Hour), job, WithGroup("cache"))
+	c.RunNow(a)
+	c.RunNow(b)
+
+	for range 2 {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("jobs in different groups did not run concurrently")
+		}
+	}
+	close(release)
+	c.WaitJobs()
+}
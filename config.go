@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"reflect"
+	"slices"
+	"time"
+)
+
+// NamedSchedule 描述一个按名称管理的任务，用于ApplyConfig
+type NamedSchedule struct {
+	Name     string   // 任务名称，在一份配置中唯一
+	Schedule Schedule // 任务调度器
+	Job      Job      // 任务实例
+}
+
+// Diff 汇总ApplyConfig对任务列表的修改，各列表按ID升序排列
+type Diff struct {
+	Added   []EntryID // 新添加的任务
+	Updated []EntryID // 调度器发生变化而重新调度的任务
+	Removed []EntryID // 配置中不存在而被删除的任务
+}
+
+// ApplyConfig 按名称将任务列表与配置对齐
+// 配置中新出现的名称会添加为新任务；已存在的名称会替换其任务实例，
+// 调度器发生变化时重新计算下次执行时间，ID和Prev保持不变；
+// 配置中不存在的有名称任务会被删除，没有名称的任务不受影响
+// 整个过程持有任务列表的锁，主循环不会看到只应用了一部分的配置
+// 与Reschedule相同，已暂停的任务只替换调度器，恢复时再计算下次执行时间
+// 名称重复时以后出现的为准；名称为空、调度器或任务为nil，或设置了WithMinInterval且调度间隔过短的配置项
+// 会被忽略并记录Error日志，没有名称的配置项无法与已有任务对应，每次应用都会重复添加
+// 返回值在Diff之外增加了error：调用Drain之后再次Start之前返回ErrDraining，不做任何修改
+func (c *Cron) ApplyConfig(configs []NamedSchedule) (Diff, error) {
+	wanted := make(map[string]NamedSchedule, len(configs))
+	var order []string
+	for _, cfg := range configs {
+		if cfg.Name == "" {
+			c.logger.Error("config rejected", "error", "entry name cannot be empty")
+			continue
+		}
+		if err := checkEntry(&Entry{Schedule: cfg.Schedule, Job: cfg.Job}); err != nil {
+			c.logger.Error("config rejected", "name", cfg.Name, "error", err)
+			continue
+		}
+		c.bindLocation(cfg.Schedule)
+		if err := c.checkInterval(cfg.Schedule); err != nil {
+			c.logger.Error("config rejected", "name", cfg.Name, "error", err)
+			continue
+		}
+		if _, ok := wanted[cfg.Name]; !ok {
+			order = append(order, cfg.Name)
+		}
+		wanted[cfg.Name] = cfg
+	}
+
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.draining {
+		return Diff{}, ErrDraining
+	}
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+
+	now := c.now()
+	var diff Diff
+	existing := make(map[string]bool)
+	for i, e := range c.entries {
+		if e.Name == "" {
+			continue
+		}
+		cfg, ok := wanted[e.Name]
+		if !ok {
+			diff.Removed = append(diff.Removed, e.ID)
+			continue
+		}
+		existing[e.Name] = true
+		// 替换为新的条目而不是原地修改，执行中的任务仍持有旧条目
+		updated := *e
+		updated.Job = cfg.Job
+		if !reflect.DeepEqual(e.Schedule, cfg.Schedule) {
+			updated.Schedule = cfg.Schedule
+			if c.running && !updated.Paused {
+				updated.Next = c.scheduleNext(&updated, now)
+			}
+			diff.Updated = append(diff.Updated, e.ID)
+			c.logger.Info("rescheduled", "now", now, "entry", e.ID, "next", updated.Next)
+			c.record(EventScheduled, e.ID, now, updated.Next)
+		}
+		c.replaceEntry(i, &updated)
+	}
+	for _, id := range diff.Removed {
+		c.removeEntry(id)
+		c.logger.Info("removed", "entry", id)
+		c.record(EventRemoved, id, now, time.Time{})
+	}
+	for _, name := range order {
+		if existing[name] {
+			continue
+		}
+		cfg := wanted[name]
+		c.nextID++
+		e := &Entry{ID: c.nextID, Name: name, Schedule: cfg.Schedule, Job: cfg.Job}
+		if c.running {
+			e.Next = c.scheduleNext(e, now)
+		}
+		c.appendEntry(e)
+		diff.Added = append(diff.Added, e.ID)
+		c.logEntry("added", e, "now", now, "entry", e.ID, "next", e.Next)
+		c.record(EventAdded, e.ID, now, e.Next)
+	}
+
+	slices.Sort(diff.Updated)
+	slices.Sort(diff.Removed)
+	if c.running {
+		c.wakeUp()
+	}
+	return diff, nil
+}
+
+// Config 是调度器生效配置的快照，由Config方法返回
+// 只包含可以用值表示的配置，Logger、回调函数等不包含在内
+type Config struct {
+	Location              *time.Location // 调度使用的时区
+	PanicPolicy           PanicPolicy    // 任务panic时的处理策略
+	ShardIndex            int            // 当前实例负责的分片序号
+	ShardTotal            int            // 分片总数，0表示未启用分片
+	ValidateOnStart       bool           // 启动前是否检查所有任务
+	EventBuffer           int            // 保留的调度决策事件数量，0表示不记录
+	MinInterval           time.Duration  // 允许的最小调度间隔，0表示不限制
+	IntervalFloor         time.Duration  // 固定间隔短于此值时记录日志，0表示不检查
+	ArchiveRemoved        bool           // 是否归档被删除的任务
+	ArchiveLimit          int            // 归档保留的最大任务数
+	HardDeadline          time.Duration  // 单次执行的截止时间，0表示不限制
+	LeaderCheck           bool           // 是否设置了leader判断函数
+	Locker                bool           // 是否设置了分布式锁
+	MissedPolicy          MissedPolicy   // 错过多次执行时的处理策略
+	CatchUpMaxRuns        int            // 每次补跑的最大次数，0表示不限制
+	CatchUpWithin         time.Duration  // 只补跑最近这段时间内错过的执行
+	SlowScheduleThreshold time.Duration  // Schedule.Next的耗时阈值，0表示不检查
+	AutoJitter            float64        // DelaySchedule任务随机延迟占间隔的最大比例
+	OutputLimit           int            // LastOutput保留的最大字节数
+	MaxConcurrent         int            // 同时执行的最大任务数，0表示不限制
+	SkipWhenFull          bool           // 达到并发上限时是否跳过新触发的任务
+}
+
+// Config 返回调度器当前生效的配置
+// 用于排查调度器是否使用了预期的配置，例如时区
+func (c *Cron) Config() Config {
+	cfg := Config{
+		Location:              c.location,
+		PanicPolicy:           c.panicPolicy,
+		ShardIndex:            c.shardIndex,
+		ShardTotal:            c.shardTotal,
+		ValidateOnStart:       c.validateOnStart,
+		MinInterval:           c.minInterval,
+		IntervalFloor:         c.intervalFloor,
+		ArchiveRemoved:        c.archive,
+		ArchiveLimit:          c.archiveLimit,
+		HardDeadline:          c.hardDeadline,
+		LeaderCheck:           c.isLeader != nil,
+		Locker:                c.locker != nil,
+		MissedPolicy:          c.missedPolicy,
+		CatchUpMaxRuns:        c.catchUpMax,
+		CatchUpWithin:         c.catchUpWithin,
+		SlowScheduleThreshold: c.slowSchedule,
+		AutoJitter:            c.autoJitter,
+		OutputLimit:           c.outputLimit,
+		MaxConcurrent:         cap(c.slots),
+		SkipWhenFull:          c.skipWhenFull,
+	}
+	if c.events != nil {
+		cfg.EventBuffer = len(c.events.buf)
+	}
+	return cfg
+}
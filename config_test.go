@@ -0,0 +1,135 @@
+package cron
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestApplyConfig verifies that a reload adds, reschedules and removes entries by name
+func TestApplyConfig(t *testing.T) {
+	c := New()
+	unnamed := c.AddFunc(&TestSchedule{}, func() {})
+	job := FuncJob(func() {})
+
+	diff, err := c.ApplyConfig([]NamedSchedule{
+		{Name: "a", Schedule: Every(time.Hour), Job: job},
+		{Name: "b", Schedule: Every(time.Hour), Job: job},
+		{Name: "c", Schedule: Every(time.Hour), Job: job},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (Diff{Added: []EntryID{2, 3, 4}}); !reflect.DeepEqual(diff, want) {
+		t.Fatalf("expected diff %+v, got %+v", want, diff)
+	}
+
+	c.Start()
+	defer c.Stop()
+
+	diff, _ = c.ApplyConfig([]NamedSchedule{
+		{Name: "a", Schedule: Every(time.Minute), Job: job},
+		{Name: "b", Schedule: Every(time.Hour), Job: job},
+		{Name: "d", Schedule: Every(time.Hour), Job: job},
+	})
+	want := Diff{Added: []EntryID{5}, Updated: []EntryID{2}, Removed: []EntryID{4}}
+	if !reflect.DeepEqual(diff, want) {
+		t.Fatalf("expected diff %+v, got %+v", want, diff)
+	}
+
+	names := map[EntryID]string{}
+	for _, e := range c.Entries() {
+		names[e.ID] = e.Name
+	}
+	wantNames := map[EntryID]string{unnamed: "", 2: "a", 3: "b", 5: "d"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("expected entries %v, got %v", wantNames, names)
+	}
+
+	after, _ := c.Entry(2)
+	if limit := time.Now().Add(time.Minute); after.Next.After(limit) {
+		t.Errorf("expected entry a to be rescheduled within a minute, got %v", after.Next)
+	}
+	if after.Schedule != Every(time.Minute) {
+		t.Errorf("expected entry a to use the new schedule, got %v", after.Schedule)
+	}
+}
+
+// TestApplyConfigEmptyName verifies that configs without a name are skipped instead of added on every reload
+func TestApplyConfigEmptyName(t *testing.T) {
+	logger := &recordingLogger{}
+	c := New(WithLogger(logger))
+	configs := []NamedSchedule{
+		{Name: "a", Schedule: Every(time.Hour), Job: FuncJob(func() {})},
+		{Schedule: Every(time.Hour), Job: FuncJob(func() {})},
+	}
+	for range 3 {
+		if _, err := c.ApplyConfig(configs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := c.Len(); n != 1 {
+		t.Errorf("expected only the named entry after repeated reloads, got %d entries", n)
+	}
+	if !slices.Contains(logger.messages, "error config rejected") {
+		t.Errorf("expected the unnamed config to be logged as rejected, got %v", logger.messages)
+	}
+}
+
+// TestApplyConfigPaused verifies that a paused entry is not rescheduled until it is resumed
+func TestApplyConfigPaused(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(WithClock(NewFakeClock(start)))
+	job := FuncJob(func() {})
+	diff, _ := c.ApplyConfig([]NamedSchedule{{Name: "a", Schedule: Every(time.Hour), Job: job}})
+	id := diff.Added[0]
+	c.Start()
+	defer c.Stop()
+	c.PauseEntry(id)
+	before, _ := c.Entry(id)
+
+	if _, err := c.ApplyConfig([]NamedSchedule{{Name: "a", Schedule: Every(time.Minute), Job: job}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, _ := c.Entry(id); !e.Paused || !e.Next.Equal(before.Next) {
+		t.Errorf("expected paused entry to keep next %v, got paused %v next %v", before.Next, e.Paused, e.Next)
+	}
+	c.ResumeEntry(id)
+	if e, _ := c.Entry(id); !e.Next.Equal(start.Add(time.Minute)) {
+		t.Errorf("expected resumed entry to use the new schedule, got %v", e.Next)
+	}
+}
+
+// TestConfig verifies that Config reflects the applied options
+func TestConfig(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	c := New(
+		WithLocation(loc),
+		WithPanicPolicy(CrashAfter(3)),
+		WithShardInfo(1, 4),
+		WithEventBuffer(16),
+		WithMinInterval(time.Minute),
+		WithCatchUpLimit(2, time.Hour),
+		WithAutoJitter(0.1),
+	)
+	cfg := c.Config()
+	if cfg.Location != loc {
+		t.Errorf("expected location %v, got %v", loc, cfg.Location)
+	}
+	if cfg.PanicPolicy != CrashAfter(3) {
+		t.Errorf("expected panic policy %+v, got %+v", CrashAfter(3), cfg.PanicPolicy)
+	}
+	if cfg.ShardIndex != 1 || cfg.ShardTotal != 4 {
+		t.Errorf("expected shard 1/4, got %d/%d", cfg.ShardIndex, cfg.ShardTotal)
+	}
+	if cfg.EventBuffer != 16 || cfg.MinInterval != time.Minute {
+		t.Errorf("expected event buffer 16 and min interval 1m, got %d and %v", cfg.EventBuffer, cfg.MinInterval)
+	}
+	if cfg.CatchUpMaxRuns != 2 || cfg.CatchUpWithin != time.Hour || cfg.AutoJitter != 0.1 {
+		t.Errorf("unexpected catch-up or jitter settings: %+v", cfg)
+	}
+	if cfg.ArchiveRemoved || cfg.ArchiveLimit != defaultArchiveLimit || cfg.OutputLimit != defaultOutputLimit {
+		t.Errorf("expected defaults for unset options, got %+v", cfg)
+	}
+}
@@ -2,8 +2,10 @@ package cron
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,17 +21,96 @@ import (
 //
 //	c.Start()
 type Cron struct {
-	entries   []*Entry       // 所有已注册的定时任务
-	stop      chan struct{}  // 停止信号通道
-	add       chan *Entry    // 添加任务的通道
-	remove    chan EntryID   // 删除任务的通道
-	running   bool           // 调度器运行状态
-	runningMu sync.Mutex     // 保护running状态的互斥锁
-	entriesMu sync.RWMutex   // 保护entries的读写锁
-	location  *time.Location // 时区信息
-	nextID    EntryID        // 下一个任务ID
-	jobWaiter sync.WaitGroup // 等待所有任务完成的WaitGroup
-	logger    Logger         // 日志接口
+	entries    []*Entry           // 所有已注册的定时任务
+	byID       map[EntryID]*Entry // 按ID索引entries中的任务，与entries同步修改
+	stop       chan struct{}      // 停止信号通道
+	add        chan *Entry        // 添加任务的通道
+	added      chan struct{}      // 主循环完成添加后的确认通道
+	removed    chan struct{}      // 主循环完成删除后的确认通道
+	remove     chan EntryID       // 删除任务的通道
+	wake       chan struct{}      // 唤醒主循环重新计算定时器的通道
+	running    bool               // 调度器运行状态
+	draining   bool               // 是否正在通过Drain停止，期间拒绝添加任务，由runningMu保护
+	runningMu  sync.Mutex         // 保护running状态的互斥锁
+	entriesMu  sync.RWMutex       // 保护entries的读写锁
+	location   *time.Location     // 时区信息
+	nextID     EntryID            // 下一个任务ID
+	jobWaiter  sync.WaitGroup     // 等待所有任务完成的WaitGroup
+	loopWaiter sync.WaitGroup     // 等待主循环及其启动的通知goroutine退出的WaitGroup
+	stopping   chan struct{}      // 每次Stop时关闭并替换，通知后台goroutine调度器已停止，由runningMu保护
+	logger     Logger             // 日志接口
+
+	ctxMu      sync.Mutex         // 保护jobCtx和cancelJobs的互斥锁
+	jobCtx     context.Context    // 传给支持context的任务的上下文
+	cancelJobs context.CancelFunc // 取消jobCtx，由Stop调用
+
+	panicPolicy PanicPolicy  // 任务panic时的处理策略
+	abort       func(any)    // 达到panic阈值时调用的中止函数
+	panics      atomic.Int64 // 累计panic次数
+
+	recoverHandler func(EntryID, any, []byte) // 任务panic时代替日志调用的处理函数
+
+	recorder DurationRecorder // 记录每次执行耗时，为nil时不记录
+	metrics  Metrics          // 记录执行次数、耗时和panic，为nil时不记录
+
+	shardIndex int // 当前实例负责的分片序号
+	shardTotal int // 分片总数，0表示未启用分片
+
+	validateOnStart bool // 启动前是否检查所有任务的调度器
+
+	exclusiveMu sync.RWMutex // 独占任务持有写锁，普通任务持有读锁
+
+	events *eventRing   // 最近的调度决策事件，为nil时不记录
+	subsMu sync.Mutex   // 保护subs
+	subs   []chan Event // 通过Events订阅事件的通道
+
+	minInterval   time.Duration // 允许的最小调度间隔，0表示不限制
+	intervalFloor time.Duration // 固定间隔短于此值时记录日志，0表示不检查
+
+	archive      bool    // 是否归档被删除的任务
+	archiveLimit int     // 归档保留的最大任务数
+	archived     []Entry // 被删除任务的归档，由entriesMu保护
+
+	onNextChange  func(next time.Time, id EntryID) // 最早执行时间变化时的回调
+	nextChangeSeq atomic.Uint64                    // 最早执行时间变化的序号
+	nextChangeMu  sync.Mutex                       // 串行化回调，保护nextDelivered
+	nextDelivered uint64                           // 已经通知的最大序号
+
+	hardDeadline time.Duration // 单次执行的截止时间，0表示不限制
+	leakedJobs   atomic.Int64  // 超过截止时间和宽限期仍未结束的执行次数
+
+	isLeader func() bool // 返回当前实例是否应当执行任务，nil表示总是执行
+	locker   Locker      // 多实例部署时每次按调度触发前需要取得的锁，nil表示不加锁
+
+	clock Clock // 获取当前时间和创建定时器的时钟
+
+	missedPolicy  MissedPolicy  // 错过多次执行时的处理策略
+	catchUpMax    int           // 每次补跑的最大次数，0表示不限制
+	catchUpWithin time.Duration // 只补跑最近这段时间内错过的执行
+
+	slowSchedule time.Duration // Schedule.Next耗时超过此值时记录日志，0表示不检查
+
+	activeMu   sync.Mutex      // 保护activeRuns
+	activeRuns map[EntryID]int // 每个任务正在执行的实例数
+
+	suspended int  // 全局暂停的层数，大于0时不触发任何任务，由entriesMu保护
+	paused    bool // 是否通过Pause暂停，占用一层全局暂停，由entriesMu保护
+
+	outputLimit int // LastOutput保留的最大字节数
+
+	randMu     sync.Mutex // 保护rand，下次执行时间可能在主循环之外计算，*rand.Rand不是并发安全的
+	rand       Rand       // 调度器使用的随机数源，为nil时使用全局随机源
+	autoJitter float64    // DelaySchedule任务每次触发附加的随机延迟占间隔的最大比例
+
+	errorHandler func(EntryID, error) // 任务返回错误时调用的处理函数
+
+	registry *JobRegistry // LoadFromConfig按名称创建任务的注册表
+
+	slots chan struct{} // 限制同时执行任务数的信号量，为nil时不限制
+
+	groupsMu     sync.Mutex             // 保护groups
+	groups       map[string]*sync.Mutex // 各互斥组的锁，由WithGroup的任务共享
+	skipWhenFull bool                   // 达到并发上限时跳过而不是等待
 }
 
 // Job 定义了定时任务的接口
@@ -44,6 +125,8 @@ type Job interface {
 // Schedule 定义了任务调度的接口
 // Next方法接收当前时间，返回下一次任务执行的时间
 // 调度器会根据此时间安排下一次执行
+// 调度器传入的时间总是已转换为其配置的时区，按日历计算的实现应使用t.Location()，
+// DelaySchedule等按固定间隔计算的实现与时区无关；需要在Next之外获知时区时实现LocationAware
 type Schedule interface {
 	Next(time.Time) time.Time
 }
@@ -60,6 +143,34 @@ type Entry struct {
 	Next     time.Time // 下次执行时间
 	Prev     time.Time // 上次执行时间
 	Job      Job       // 任务实例
+	Paused   bool      // 是否已暂停，暂停的任务不会被触发
+	// PausedUntil 暂停任务的自动恢复时间，零值表示需要手动恢复
+	PausedUntil time.Time
+	// Removed 任务被删除的时间，仅对Archived返回的任务有效
+	Removed time.Time
+	// Name 任务名称，仅通过AddNamedFunc或ApplyConfig添加的任务有名称
+	Name string
+	// Spec 添加任务时使用的cron表达式，仅通过AddCron添加的任务有值，便于展示和编辑
+	Spec string
+	// LastDuration 最近一次执行的耗时，任务执行结束时由执行任务的goroutine更新
+	LastDuration time.Duration
+	// LastOutput 最近一次执行的输出，仅通过AddStringJob添加的任务有值，超过WithOutputLimit的部分会被截断
+	LastOutput string
+
+	parent    EntryID   // 父任务ID，非零时任务只在父任务成功完成后触发
+	shardKey  string    // 分片键，非空时任务只在负责该分片的实例上触发
+	exclusive bool      // 是否为独占任务，执行期间不会启动其他任务
+	group     string    // 互斥组名称，同组的任务不会同时执行
+	fires     *fireRing // 最近的触发时间，由主循环在持有entriesMu时更新
+
+	maxRuns    int           // 最多按调度触发的次数，0表示不限制
+	runs       int           // 已经按调度触发的次数
+	onComplete func(EntryID) // 触发次数用完且最后一次执行结束后的回调
+}
+
+// active 判断任务是否参与调度，即未暂停且存在下次执行时间
+func (e *Entry) active() bool {
+	return !e.Paused && !e.Next.IsZero()
 }
 
 // byTime 实现了sort.Interface接口，用于按Next时间排序任务
@@ -68,10 +179,10 @@ type byTime []*Entry
 func (s byTime) Len() int      { return len(s) }
 func (s byTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 func (s byTime) Less(i, j int) bool {
-	if s[i].Next.IsZero() {
+	if !s[i].active() {
 		return false
 	}
-	if s[j].Next.IsZero() {
+	if !s[j].active() {
 		return true
 	}
 	return s[i].Next.Before(s[j].Next)
@@ -82,14 +193,26 @@ func (s byTime) Less(i, j int) bool {
 func New(opts ...Option) *Cron {
 	c := &Cron{
 		entries:   nil,
+		byID:      make(map[EntryID]*Entry),
 		add:       make(chan *Entry),
+		added:     make(chan struct{}),
+		removed:   make(chan struct{}),
 		stop:      make(chan struct{}),
+		stopping:  make(chan struct{}),
 		remove:    make(chan EntryID),
+		wake:      make(chan struct{}, 1),
 		running:   false,
 		runningMu: sync.Mutex{},
 		location:  time.Local,
 		logger:    &discardLogger{},
+		abort:     defaultAbort,
+		clock:     realClock{},
+
+		archiveLimit:  defaultArchiveLimit,
+		intervalFloor: defaultIntervalFloor,
+		outputLimit:   defaultOutputLimit,
 	}
+	c.jobCtx, c.cancelJobs = context.WithCancel(context.Background())
 
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
@@ -113,10 +236,11 @@ func (f FuncJob) Run() {
 //
 //	schedule - 任务调度器，决定任务何时执行
 //	cmd - 要执行的函数
+//	opts - 可选的任务配置
 //
-// 返回任务ID，可用于后续删除任务
-func (c *Cron) AddFunc(schedule Schedule, cmd func()) EntryID {
-	return c.AddJob(schedule, FuncJob(cmd))
+// 返回任务ID，可用于后续删除任务；schedule或cmd为nil时不会添加，记录Error日志并返回0
+func (c *Cron) AddFunc(schedule Schedule, cmd func(), opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, FuncJob(cmd), opts...)
 }
 
 // AddJob 添加一个任务到调度器
@@ -124,25 +248,93 @@ func (c *Cron) AddFunc(schedule Schedule, cmd func()) EntryID {
 //
 //	schedule - 任务调度器，决定任务何时执行
 //	cmd - 实现了Job接口的任务实例
+//	opts - 可选的任务配置
 //
 // 返回任务ID，可用于后续删除任务
 // 如果调度器未运行，任务会立即添加到任务列表
-// 如果调度器已运行，任务会通过通道异步添加
-func (c *Cron) AddJob(schedule Schedule, cmd Job) EntryID {
-	c.runningMu.Lock()
-	defer c.runningMu.Unlock()
-	c.nextID++
-	entry := &Entry{
-		ID:       c.nextID,
+// 如果调度器已运行，任务通过通道交给主循环添加，主循环计算好下次执行时间后才返回
+// schedule或cmd为nil，或设置了WithMinInterval且调度间隔过短时，任务不会被添加，记录Error日志并返回0
+// 需要获得具体错误时使用AddJobE
+func (c *Cron) AddJob(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	id, err := c.AddJobE(schedule, cmd, opts...)
+	if err != nil {
+		c.logger.Error("add job rejected", "error", err)
+	}
+	return id
+}
+
+// AddJobE 与AddJob相同，但在任务无法添加时返回错误而不是记录日志
+// schedule为nil时返回ErrNilSchedule，cmd为nil时返回ErrNilJob，
+// 调度间隔短于WithMinInterval设置的最小间隔时返回ErrIntervalTooShort
+func (c *Cron) AddJobE(schedule Schedule, cmd Job, opts ...EntryOption) (EntryID, error) {
+	return c.addEntry(&Entry{
 		Schedule: schedule,
 		Job:      cmd,
+	}, opts...)
+}
+
+// AddFuncE 与AddFunc相同，但在任务无法添加时返回错误，错误与AddJobE相同
+func (c *Cron) AddFuncE(schedule Schedule, cmd func(), opts ...EntryOption) (EntryID, error) {
+	return c.AddJobE(schedule, FuncJob(cmd), opts...)
+}
+
+// AddCron 解析cron表达式并添加一个函数作为定时任务
+// 表达式格式见Parse，表达式不合法或调度间隔短于WithMinInterval设置的最小间隔时返回错误
+func (c *Cron) AddCron(spec string, cmd func(), opts ...EntryOption) (EntryID, error) {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return c.addEntry(&Entry{
+		Schedule: schedule,
+		Job:      FuncJob(cmd),
+		Spec:     spec,
+	}, opts...)
+}
+
+// addEntry 应用任务配置，为任务分配ID并加入调度器，返回任务ID
+// 调度器或任务为nil时返回ErrNilSchedule或ErrNilJob，调度间隔短于最小间隔时返回ErrIntervalTooShort，
+// 调用Drain之后再次Start之前返回ErrDraining
+func (c *Cron) addEntry(entry *Entry, opts ...EntryOption) (EntryID, error) {
+	for _, opt := range opts {
+		opt(entry)
+	}
+	if err := checkEntry(entry); err != nil {
+		return 0, err
+	}
+	c.bindLocation(entry.Schedule)
+	if err := c.checkInterval(entry.Schedule); err != nil {
+		return 0, err
+	}
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	if c.draining {
+		return 0, ErrDraining
 	}
+	c.nextID++
+	entry.ID = c.nextID
 	if !c.running {
-		c.entries = append(c.entries, entry)
+		c.entriesMu.Lock()
+		c.appendEntry(entry)
+		c.entriesMu.Unlock()
+		c.record(EventAdded, entry.ID, c.now(), time.Time{})
 	} else {
+		// 等待主循环计算下次执行时间并加入任务列表，返回后任务已可被查询和调度
 		c.add <- entry
+		<-c.added
+	}
+	return entry.ID, nil
+}
+
+// checkEntry 检查任务的调度器和任务是否为nil，避免之后在主循环中panic
+func checkEntry(e *Entry) error {
+	if e.Schedule == nil {
+		return ErrNilSchedule
+	}
+	if isNilJob(e.Job) {
+		return ErrNilJob
 	}
-	return entry.ID
+	return nil
 }
 
 // Location 返回当前调度器使用的时区
@@ -151,29 +343,103 @@ func (c *Cron) Location() *time.Location {
 }
 
 // Remove 从调度器中删除指定ID的任务
-// 如果调度器正在运行，会通过通道异步删除
+// 如果调度器正在运行，会交给主循环删除并等待其完成，返回后任务已不在任务列表中
 // 如果调度器未运行，会立即删除
 func (c *Cron) Remove(id EntryID) {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
 	if c.running {
 		c.remove <- id
+		<-c.removed
 	} else {
+		c.deleteEntry(id)
+	}
+}
+
+// Reschedule 替换指定ID任务的调度器，ID、Prev等执行历史保持不变
+// 调度器运行且任务未暂停时从当前时间按新的调度器重新计算下次执行时间
+// 任务不存在时返回ErrEntryNotFound，s为nil时返回ErrNilSchedule，
+// 设置了WithMinInterval且新调度间隔过短时返回ErrIntervalTooShort
+func (c *Cron) Reschedule(id EntryID, s Schedule) error {
+	if s == nil {
+		return ErrNilSchedule
+	}
+	c.bindLocation(s)
+	if err := c.checkInterval(s); err != nil {
+		return err
+	}
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	for i, e := range c.entries {
+		if e.ID != id {
+			continue
+		}
+		// 与ApplyConfig相同，替换为新的条目而不是原地修改，执行中的任务仍持有旧条目
+		updated := *e
+		updated.Schedule = s
+		now := c.now()
+		if c.running && !updated.Paused {
+			updated.Next = c.scheduleNext(&updated, now)
+			c.wakeUp()
+		}
+		c.replaceEntry(i, &updated)
+		c.logEntry("rescheduled", &updated, "now", now, "entry", id, "next", updated.Next)
+		c.record(EventScheduled, id, now, updated.Next)
+		return nil
+	}
+	return ErrEntryNotFound
+}
+
+// RemoveAll 删除调度器中的所有任务
+// 调度器运行时与ApplyConfig一样持有任务列表的锁直接删除，并唤醒主循环回到空闲等待，
+// 返回后Entries为空，已经开始执行的任务会继续完成
+func (c *Cron) RemoveAll() {
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	c.entriesMu.Lock()
+	now := c.now()
+	ids := make([]EntryID, 0, len(c.entries))
+	for _, e := range c.entries {
+		ids = append(ids, e.ID)
+	}
+	for _, id := range ids {
 		c.removeEntry(id)
+		c.logger.Info("removed", "entry", id)
+		c.record(EventRemoved, id, now, time.Time{})
+	}
+	c.entriesMu.Unlock()
+	if c.running {
+		c.wakeUp()
 	}
 }
 
 // Start 启动调度器的后台运行
 // 此方法会启动一个goroutine执行run方法
 // 如果调度器已经在运行，此方法会直接返回
+// 设置了WithValidateOnStart且检查失败时不会启动
 func (c *Cron) Start() {
+	_ = c.StartE()
+}
+
+// StartE 与Start相同，但会返回无法启动的原因
+// 调度器已经在运行时返回ErrSchedulerRunning，WithValidateOnStart检查失败时返回检查的错误
+// 用于在生命周期管理代码中发现重复启动
+func (c *Cron) StartE() error {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
 	if c.running {
-		return
+		return ErrSchedulerRunning
+	}
+	if err := c.validateStart(); err != nil {
+		return err
 	}
 	c.running = true
+	c.draining = false
+	c.loopWaiter.Add(1)
 	go c.run()
+	return nil
 }
 
 // Run 启动调度器并阻塞当前goroutine
@@ -181,60 +447,133 @@ func (c *Cron) Start() {
 // 通常在主goroutine中使用Run，在其他情况下使用Start
 func (c *Cron) Run() {
 	c.runningMu.Lock()
-	if c.running {
+	if c.running || c.validateStart() != nil {
 		c.runningMu.Unlock()
 		return
 	}
 	c.running = true
+	c.draining = false
+	c.loopWaiter.Add(1)
 	c.runningMu.Unlock()
 	c.run()
 }
 
+// validateStart 在设置了WithValidateOnStart时检查所有任务，返回不能启动的原因
+func (c *Cron) validateStart() error {
+	if !c.validateOnStart {
+		return nil
+	}
+	if err := c.Validate(); err != nil {
+		c.logger.Error("validation failed, not starting", "error", err)
+		return err
+	}
+	return nil
+}
+
 // run 是调度器的主循环
 // 负责维护任务列表、计算下次执行时间和触发任务
 // 不应直接调用，应通过Start或Run方法启动
 func (c *Cron) run() {
-
+	defer c.loopWaiter.Done()
+	// running置为true之前添加的任务已在列表中，之后添加的任务经由c.add交给主循环，
+	// 持有锁计算初始执行时间，每个任务恰好被调度一次
+	c.entriesMu.Lock()
 	now := c.now()
 	for _, entry := range c.entries {
-		entry.Next = entry.Schedule.Next(now)
-		c.logger.Info("schedule", "now", now, "entry", entry.ID, "next", entry.Next)
+		entry.Next = c.scheduleNext(entry, now)
+		c.logEntry("schedule", entry, "now", now, "entry", entry.ID, "next", entry.Next)
+		c.record(EventScheduled, entry.ID, now, entry.Next)
 	}
+	c.entriesMu.Unlock()
 
+	var head Entry // 上一次通知的最早任务
 	for {
-		c.entriesMu.RLock()
+		c.entriesMu.Lock()
 		sort.Sort(byTime(c.entries))
-		c.entriesMu.RUnlock()
+		if c.onNextChange != nil {
+			var current Entry
+			if len(c.entries) > 0 && c.entries[0].active() {
+				current = *c.entries[0]
+			}
+			if current.ID != head.ID || !current.Next.Equal(head.Next) {
+				head = current
+				c.notifyNextChange(head.Next, head.ID)
+			}
+		}
 
-		var timer *time.Timer
-		if len(c.entries) == 0 || c.entries[0].Next.IsZero() {
-			timer = time.NewTimer(100000 * time.Hour)
+		var timer Timer
+		// 全局暂停或非leader时不等待任何任务，直到被添加、删除或唤醒后重新判断
+		if wake := c.nextWake(); wake.IsZero() || c.suspended > 0 || !c.leading() {
+			timer = c.clock.NewTimer(100000 * time.Hour)
 		} else {
-			timer = time.NewTimer(c.entries[0].Next.Sub(now))
+			// 上一轮的now可能已过时(例如触发任务或计算下次执行时间耗时较长)，
+			// 以当前时间计算等待时长，保证定时器在任务的执行时间准时触发
+			timer = c.clock.NewTimer(wake.Sub(c.now()))
 		}
+		c.entriesMu.Unlock()
 
 		for {
 			select {
-			case now = <-timer.C:
+			case now = <-timer.C():
 				now = now.In(c.location)
 				c.logger.Info("wake", "now", now)
+				// 先处理同时到达的添加和删除请求，避免刚删除的任务再触发一次
+				c.drainPending(now)
 
+				c.entriesMu.Lock()
+				c.resumeDue(now)
 				for _, e := range c.entries {
-					if e.Next.After(now) || e.Next.IsZero() {
+					if c.suspended > 0 {
+						c.logger.Info("skip", "now", now, "reason", "suspended")
+						break
+					}
+					if !c.leading() {
+						// 失去leader身份的到期任务保持到期状态，重新成为leader后立即执行
+						c.logger.Info("skip", "now", now, "reason", "leader")
+						break
+					}
+					if !e.active() || e.Next.After(now) {
 						break
 					}
-					c.startJob(e.Job)
-					e.Prev = e.Next
-					e.Next = e.Schedule.Next(now)
-					c.logger.Info("run", "now", now, "entry", e.ID, "next", e.Next)
+					if !c.ownsShard(e) {
+						c.logEntry("skip", e, "now", now, "entry", e.ID, "reason", "shard")
+						c.record(EventSkipped, e.ID, now, e.Next)
+					} else if runs := c.dueRuns(e, now); len(runs) == 0 {
+						c.logEntry("skip", e, "now", now, "entry", e.ID, "reason", "catch-up")
+						c.record(EventSkipped, e.ID, now, e.Next)
+					} else {
+						for _, due := range runs {
+							if e.exhausted() {
+								break
+							}
+							c.startJobAt(e, due, c.countRun(e))
+							c.recordFire(e, now)
+							c.record(EventFired, e.ID, now, due)
+							e.Prev = due
+						}
+						if e.exhausted() {
+							e.Next = time.Time{}
+							c.logEntry("completed", e, "now", now, "entry", e.ID, "runs", e.runs)
+							continue
+						}
+						if _, ok := e.Schedule.(FixedDelaySchedule); ok {
+							// 固定延迟的任务在执行完成后才计算下次执行时间
+							e.Next = time.Time{}
+							c.logEntry("run", e, "now", now, "entry", e.ID, "next", e.Next)
+							continue
+						}
+					}
+					e.Next = c.scheduleNext(e, now)
+					c.logEntry("run", e, "now", now, "entry", e.ID, "next", e.Next)
+					c.record(EventScheduled, e.ID, now, e.Next)
 				}
+				c.entriesMu.Unlock()
 
 			case newEntry := <-c.add:
 				timer.Stop()
 				now = c.now()
-				newEntry.Next = newEntry.Schedule.Next(now)
-				c.entries = append(c.entries, newEntry)
-				c.logger.Info("added", "now", now, "entry", newEntry.ID, "next", newEntry.Next)
+				c.insertEntry(newEntry, now)
+				c.added <- struct{}{}
 
 			case <-c.stop:
 				timer.Stop()
@@ -244,8 +583,12 @@ func (c *Cron) run() {
 			case id := <-c.remove:
 				timer.Stop()
 				now = c.now()
-				c.removeEntry(id)
-				c.logger.Info("removed", "entry", id)
+				c.deleteEntry(id)
+				c.removed <- struct{}{}
+
+			case <-c.wake:
+				timer.Stop()
+				now = c.now()
 			}
 
 			break
@@ -253,55 +596,609 @@ func (c *Cron) run() {
 	}
 }
 
+// drainPending 处理所有已在等待的添加和删除请求，没有等待的请求时立即返回
+func (c *Cron) drainPending(now time.Time) {
+	for {
+		select {
+		case newEntry := <-c.add:
+			c.insertEntry(newEntry, now)
+			c.added <- struct{}{}
+		case id := <-c.remove:
+			c.deleteEntry(id)
+			c.removed <- struct{}{}
+		default:
+			return
+		}
+	}
+}
+
+// insertEntry 计算新任务的下次执行时间并加入任务列表
+func (c *Cron) insertEntry(e *Entry, now time.Time) {
+	e.Next = c.scheduleNext(e, now)
+	c.entriesMu.Lock()
+	c.appendEntry(e)
+	c.entriesMu.Unlock()
+	c.logEntry("added", e, "now", now, "entry", e.ID, "next", e.Next)
+	c.record(EventAdded, e.ID, now, e.Next)
+}
+
+// deleteEntry 从任务列表中删除指定ID的任务
+func (c *Cron) deleteEntry(id EntryID) {
+	c.entriesMu.Lock()
+	c.removeEntry(id)
+	c.entriesMu.Unlock()
+	c.logger.Info("removed", "entry", id)
+	c.record(EventRemoved, id, c.now(), time.Time{})
+}
+
+// nextWake 返回主循环下一次需要醒来的时间，零值表示没有需要等待的事件
+// 取最早的任务执行时间和暂停任务的自动恢复时间中较早者
+// 调用者需要持有entriesMu，且entries已按byTime排序
+func (c *Cron) nextWake() time.Time {
+	var wake time.Time
+	if len(c.entries) > 0 && c.entries[0].active() {
+		wake = c.entries[0].Next
+	}
+	for _, e := range c.entries {
+		if e.Paused && !e.PausedUntil.IsZero() && (wake.IsZero() || e.PausedUntil.Before(wake)) {
+			wake = e.PausedUntil
+		}
+	}
+	return wake
+}
+
+// resumeDue 恢复所有自动恢复时间已到的暂停任务，并从now重新计算下次执行时间
+// 调用者需要持有entriesMu
+func (c *Cron) resumeDue(now time.Time) {
+	for _, e := range c.entries {
+		if e.Paused && !e.PausedUntil.IsZero() && !e.PausedUntil.After(now) {
+			e.Paused = false
+			e.PausedUntil = time.Time{}
+			e.Next = c.scheduleNext(e, now)
+			c.logEntry("resumed", e, "now", now, "entry", e.ID, "next", e.Next)
+			c.record(EventScheduled, e.ID, now, e.Next)
+		}
+	}
+}
+
+// wakeUp 通知主循环重新计算定时器，不会阻塞
+// 在主循环之外修改了任务的调度状态后调用
+func (c *Cron) wakeUp() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// WakeNow 让主循环立即醒来，重新计算下一次唤醒时间
+// 只会执行已经到期的任务，未到期的任务不受影响
+// 用于外部状态(例如WithLeaderCheck的leader身份)变化后，无需添加或删除任务即可让调度器重新判断
+func (c *Cron) WakeNow() {
+	c.wakeUp()
+}
+
+// leading 返回当前实例是否应当执行任务
+func (c *Cron) leading() bool {
+	return c.isLeader == nil || c.isLeader()
+}
+
 // startJob 启动一个任务的执行
 // 会启动新的goroutine执行任务，并处理可能的panic
-// 参数j是要执行的任务
-func (c *Cron) startJob(j Job) {
+// 参数e是要执行的任务条目，只会读取其创建后不再变化的字段
+// 独占任务执行期间其他任务会等待，任务成功完成后会触发依赖它的子任务
+func (c *Cron) startJob(e *Entry) {
+	c.startJobAt(e, time.Time{}, nil)
+}
+
+// startJobAt 与startJob相同，due为按调度触发时的计划时间，手动执行时为零值
+// 设置了WithLocker时按调度触发的执行需要先取得锁；then不为nil时在任务执行结束或被跳过后调用
+func (c *Cron) startJobAt(e *Entry, due time.Time, then func()) {
+	ctx := c.jobContext()
 	c.jobWaiter.Add(1)
+	c.jobStarted(e.ID)
 	go func() {
+		defer c.jobWaiter.Done()
 		defer func() {
-			if r := recover(); r != nil {
-				c.logger.Error("job panic recovered", "error", r)
+			// 主循环在触发时清空了固定延迟任务的下次执行时间，
+			// 无论本次是执行完成还是因锁或并发限制被跳过都要重新计算，否则任务不会再被触发
+			if _, ok := e.Schedule.(FixedDelaySchedule); ok {
+				c.rescheduleAfterRun(e.ID)
+			}
+			if then != nil {
+				then()
 			}
-			c.jobWaiter.Done()
 		}()
-		j.Run()
+		if c.locker != nil && !due.IsZero() {
+			release, ok := c.acquireLock(ctx, e, due)
+			if !ok {
+				c.jobFinished(e.ID)
+				return
+			}
+			defer release()
+		}
+		if ran, err := c.runGuarded(ctx, e, e.Job); ran && err == nil {
+			c.startDependents(e.ID)
+		}
+	}()
+}
+
+// runGuarded 在任务组、并发名额和WithExclusive的限制下执行任务e的一个实例j，返回是否执行及其错误
+// 调用者需要已经通过jobStarted登记该实例，返回前会登记其结束；
+// 没有空闲名额且设置了WithSkipWhenFull时跳过执行并返回false
+func (c *Cron) runGuarded(ctx context.Context, e *Entry, j Job) (bool, error) {
+	if e.group != "" {
+		// 先等待同组的任务结束再占用并发名额，等待期间不占用名额
+		mu := c.groupLock(e.group)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+	if !c.acquireSlot(e.ID) {
+		c.jobFinished(e.ID)
+		return false, nil
+	}
+	defer c.releaseSlot()
+	if e.exclusive {
+		c.exclusiveMu.Lock()
+		defer c.exclusiveMu.Unlock()
+	} else {
+		c.exclusiveMu.RLock()
+		defer c.exclusiveMu.RUnlock()
+	}
+	err := c.runJob(ctx, e.ID, j)
+	c.jobFinished(e.ID)
+	return true, err
+}
+
+// rescheduleAfterRun 在固定延迟任务执行完成后计算其下次执行时间并唤醒主循环
+// 任务已被删除、暂停或已有下次执行时间时不做修改
+func (c *Cron) rescheduleAfterRun(id EntryID) {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	e := c.entry(id)
+	if e == nil || e.Paused || !e.Next.IsZero() || e.exhausted() {
+		return
+	}
+	now := c.now()
+	e.Next = c.scheduleNext(e, now)
+	c.logEntry("run", e, "now", now, "entry", e.ID, "next", e.Next)
+	c.record(EventScheduled, e.ID, now, e.Next)
+	c.wakeUp()
+}
+
+// runJob 在当前goroutine中执行任务，并捕获可能的panic
+// 返回任务的错误，panic会被转换为错误返回
+// 配置了DurationRecorder时会记录本次执行的耗时，配置了Metrics时会报告执行的开始和结果
+// 配置了WithHardDeadlineLog时会为本次执行设置截止时间并监视超时
+func (c *Cron) runJob(ctx context.Context, id EntryID, j Job) (err error) {
+	if c.hardDeadline > 0 {
+		var stop func()
+		ctx, stop = c.watchDeadline(ctx, id)
+		defer stop()
+	}
+	ctx = context.WithValue(ctx, entryIDKey{}, id)
+	ctx = context.WithValue(ctx, cronKey{}, c)
+	if c.metrics != nil {
+		c.metrics.JobStarted(id)
+	}
+	c.publish(Event{Kind: EventStarted, EntryID: id, Time: c.now()})
+	start := time.Now()
+	defer func() {
+		r := recover()
+		elapsed := time.Since(start)
+		c.setLastDuration(id, elapsed)
+		if c.recorder != nil {
+			c.recorder.Record(id, elapsed)
+		}
+		if c.metrics != nil {
+			if r != nil {
+				c.metrics.JobPanicked(id)
+			} else {
+				c.metrics.JobCompleted(id, elapsed)
+			}
+		}
+		if r != nil {
+			c.publish(Event{Kind: EventPanicked, EntryID: id, Time: c.now()})
+		} else {
+			c.publish(Event{Kind: EventFinished, EntryID: id, Time: c.now()})
+		}
+		if r != nil {
+			err = fmt.Errorf("job panic: %v", r)
+			c.handlePanic(id, r)
+		}
+	}()
+	if err = invoke(ctx, j); err != nil {
+		c.logger.Error("job failed", "entry", id, "error", err)
+		c.handleError(id, err)
+	}
+	return err
+}
+
+// handleError 调用WithErrorHandler设置的处理函数，处理函数panic时只记录日志
+func (c *Cron) handleError(id EntryID, err error) {
+	if c.errorHandler == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.Error("error handler panic", "entry", id, "error", r)
+		}
 	}()
+	c.errorHandler(id, err)
+}
+
+// setLastDuration 更新任务最近一次执行的耗时，任务已被删除时忽略
+func (c *Cron) setLastDuration(id EntryID, d time.Duration) {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if e := c.entry(id); e != nil {
+		e.LastDuration = d
+	}
+}
+
+// WaitJobs 阻塞直到当前所有正在执行的任务结束，包括由它们触发的依赖任务
+// 不会停止调度器，主要用于测试中等待已触发的任务完成
+func (c *Cron) WaitJobs() {
+	c.jobWaiter.Wait()
+}
+
+// jobContext 返回当前传给任务的上下文
+func (c *Cron) jobContext() context.Context {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+	return c.jobCtx
+}
+
+// DrainBacklog 以最多maxConcurrent的并发度执行指定任务当前积压的所有触发，全部完成后返回
+// 只对使用DelayIfStillRunning包装的任务有效，其他任务或未知ID直接返回
+// 用于停机恢复后快速消化积压，正在执行的实例不受影响
+// 每次执行与按调度触发的执行一样受WithMaxConcurrent、WithExclusive和WithGroup的限制，并计入IsJobRunning；
+// 积压的触发在触发时已经取得过WithLocker的锁，执行时不再加锁
+func (c *Cron) DrainBacklog(id EntryID, maxConcurrent int) {
+	var e *Entry
+	var job *delayJob
+	c.entriesMu.RLock()
+	if e = c.entry(id); e != nil {
+		job, _ = e.Job.(*delayJob)
+	}
+	c.entriesMu.RUnlock()
+	if job == nil {
+		return
+	}
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	ctx := c.jobContext()
+	n := job.takeBacklog()
+	c.logger.Info("drain backlog", "entry", id, "runs", n, "concurrency", maxConcurrent)
+	sem := make(chan struct{}, maxConcurrent)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		wg.Add(1)
+		c.jobWaiter.Add(1)
+		c.jobStarted(id)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+				c.jobWaiter.Done()
+			}()
+			c.runGuarded(ctx, e, job.job)
+		}()
+	}
+	wg.Wait()
 }
 
 // now 返回当前时间，考虑了调度器的时区设置
 func (c *Cron) now() time.Time {
-	return time.Now().In(c.location)
+	return c.clock.Now().In(c.location)
 }
 
 // Stop 停止调度器的运行
-// 返回一个context.Context，当所有正在执行的任务完成后会被取消
-// 调用后，新的任务不会被调度，但正在执行的任务会继续完成
+// 返回一个context.Context，当主循环退出且所有正在执行的任务完成后会被取消，
+// 此时调度器启动的goroutine均已退出
+// 调用后，新的任务不会被调度；发出停止信号后会取消正在执行任务的context，
+// 支持context的任务可据此尽快返回，普通Job无法被中断，仍会执行到结束
+// 需要让已到期的任务不受取消影响地完成时使用Drain
+// 主循环从不等待任务，因此可以在任务中调用Stop；但任务不能等待返回的context，否则会等待自身而永远阻塞
+// 不要在主循环同步调用的回调(如Schedule.Next、WithLeaderCheck的检查函数)中调用Stop
 func (c *Cron) Stop() context.Context {
 	c.runningMu.Lock()
 	defer c.runningMu.Unlock()
-	if c.running {
-		c.stop <- struct{}{}
-		c.running = false
-	}
+	c.halt()
+	c.cancelJobContext()
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
+		c.loopWaiter.Wait()
 		c.jobWaiter.Wait()
 		cancel()
 	}()
 	return ctx
 }
 
+// halt 停止主循环并通知等待停止的goroutine，不等待任务结束
+// 调用者需要持有runningMu
+func (c *Cron) halt() {
+	if c.running {
+		c.stop <- struct{}{}
+		c.running = false
+	}
+	close(c.stopping)
+	c.stopping = make(chan struct{})
+}
+
+// Entries 返回所有任务的快照
+// 返回的是副本，修改它们不会影响调度器内部状态
+// 可以在调度器运行时从任意goroutine调用，主循环总是在持有锁时更新任务，
+// 因此返回的Next和Prev反映的是调用时的最新值
+// 结果按下次执行时间升序排列，时间相同时按ID升序，没有下次执行时间的任务排在最后，
+// 因此多次调用的顺序是确定的，与内部排序无关
+func (c *Cron) Entries() []Entry {
+	c.entriesMu.RLock()
+	entries := make([]Entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, *e)
+	}
+	c.entriesMu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Next.IsZero() != b.Next.IsZero() {
+			return b.Next.IsZero()
+		}
+		if !a.Next.Equal(b.Next) {
+			return a.Next.Before(b.Next)
+		}
+		return a.ID < b.ID
+	})
+	return entries
+}
+
+// EntriesInOrder 返回所有任务的快照，按ID升序即添加顺序排列
+// 与按下次执行时间排序的Entries不同，结果与调度状态无关，适合输出稳定的配置
+func (c *Cron) EntriesInOrder() []Entry {
+	c.entriesMu.RLock()
+	entries := make([]Entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, *e)
+	}
+	c.entriesMu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// RunNow 立即执行指定ID的任务一次，不改变其下次执行时间
+// 无论调度器是否运行，任务都会在新的goroutine中异步执行
+// 如果任务不存在，返回ErrEntryNotFound
+func (c *Cron) RunNow(id EntryID) error {
+	c.entriesMu.RLock()
+	e := c.entry(id)
+	c.entriesMu.RUnlock()
+	if e == nil {
+		return ErrEntryNotFound
+	}
+	c.logger.Info("run now", "entry", id)
+	c.startJob(e)
+	return nil
+}
+
+// PauseEntry 暂停指定ID的任务，暂停期间任务不会被触发
+// 如果任务不存在，返回false
+func (c *Cron) PauseEntry(id EntryID) bool {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	e := c.entry(id)
+	if e == nil {
+		return false
+	}
+	e.Paused = true
+	e.PausedUntil = time.Time{}
+	c.logger.Info("paused", "entry", id)
+	return true
+}
+
+// PauseUntil 暂停指定ID的任务，并在until时刻自动恢复
+// 恢复时从当前时间重新计算下次执行时间，适用于结束时间已知的维护窗口
+// 如果任务不存在，返回false
+func (c *Cron) PauseUntil(id EntryID, until time.Time) bool {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	e := c.entry(id)
+	if e == nil {
+		return false
+	}
+	e.Paused = true
+	e.PausedUntil = until
+	c.wakeUp()
+	c.logger.Info("paused", "entry", id, "until", until)
+	return true
+}
+
+// ResumeOptions 是ResumeEntry和Resume的可选参数
+type ResumeOptions struct {
+	// RunIfMissed 为true时，如果暂停前的下次执行时间已经过去，恢复后立即触发一次，
+	// 而不是等待下一个周期
+	RunIfMissed bool
+}
+
+// ResumeEntry 恢复被暂停的任务，并从当前时间重新计算下次执行时间
+// 可以传入ResumeOptions补跑暂停期间错过的执行，传入多个时以最后一个为准
+// 如果任务不存在，返回false
+func (c *Cron) ResumeEntry(id EntryID, opts ...ResumeOptions) bool {
+	var opt ResumeOptions
+	for _, o := range opts {
+		opt = o
+	}
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	e := c.entry(id)
+	if e == nil {
+		return false
+	}
+	if e.Paused {
+		now := c.now()
+		missed := !e.Next.IsZero() && !e.Next.After(now)
+		e.Paused = false
+		e.PausedUntil = time.Time{}
+		if opt.RunIfMissed && missed {
+			// 交给主循环立即触发一次，触发后照常计算下次执行时间
+			e.Next = now
+		} else {
+			e.Next = c.scheduleNext(e, now)
+		}
+		c.wakeUp()
+	}
+	c.logger.Info("resumed", "entry", id, "next", e.Next)
+	return true
+}
+
+// Disable 禁用指定ID的任务，任务保留在调度器中但不会被触发，直到调用Enable
+// 等同于PauseEntry，任务不存在时没有效果
+func (c *Cron) Disable(id EntryID) {
+	c.PauseEntry(id)
+}
+
+// Enable 重新启用被Disable禁用的任务，并从当前时间重新计算下次执行时间
+// 等同于不带选项的ResumeEntry，任务不存在时没有效果
+func (c *Cron) Enable(id EntryID) {
+	c.ResumeEntry(id)
+}
+
+// Entry 返回指定ID任务的快照，任务不存在时第二个返回值为false
+// 与Entries一样返回副本，可以在调度器运行时调用，适合只关心单个任务的场景
+func (c *Cron) Entry(id EntryID) (Entry, bool) {
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	if e := c.entry(id); e != nil {
+		return *e, true
+	}
+	return Entry{}, false
+}
+
+// NextRun 返回指定ID任务的下次执行时间
+// 调度器运行时返回主循环维护的最新值；任务不存在时返回零值和false，
+// 未启动、已暂停或已结束的任务返回零值和true
+func (c *Cron) NextRun(id EntryID) (time.Time, bool) {
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	if e := c.entry(id); e != nil {
+		return e.Next, true
+	}
+	return time.Time{}, false
+}
+
+// Len 返回已注册的任务数量，包括暂停的任务和依赖任务
+// 调度器运行时添加和删除都在主循环处理完成后才返回，因此Len总是反映已经返回的Add和Remove调用
+func (c *Cron) Len() int {
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	return len(c.entries)
+}
+
+// TimeUntilNext 返回距离所有任务中最早的下次执行时间还有多久，可用于健康检查
+// 只考虑未暂停且有下次执行时间的任务，调度器未运行、没有这样的任务或调度器处于全局暂停时
+// 第二个返回值为false；最早的任务已经到期但尚未触发时返回0
+func (c *Cron) TimeUntilNext() (time.Duration, bool) {
+	c.runningMu.Lock()
+	running := c.running
+	c.runningMu.Unlock()
+	if !running {
+		// Stop之后任务仍保留停止前的下次执行时间，但不会再被触发
+		return 0, false
+	}
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	if c.suspended > 0 {
+		return 0, false
+	}
+	var earliest time.Time
+	for _, e := range c.entries {
+		if e.active() && (earliest.IsZero() || e.Next.Before(earliest)) {
+			earliest = e.Next
+		}
+	}
+	if earliest.IsZero() {
+		return 0, false
+	}
+	return max(earliest.Sub(c.now()), 0), true
+}
+
+// entry 返回指定ID的任务，不存在时返回nil
+// 调用者需要持有entriesMu
+func (c *Cron) entry(id EntryID) *Entry {
+	return c.byID[id]
+}
+
+// StopWithTimeout 停止调度器，并最多等待d让正在执行的任务完成
+// 与Stop相同，调用后立即停止调度新的任务；任务在d内全部完成时返回nil，
+// 否则返回ErrShutdownTimeout，未完成的任务不会被中断，仍会在后台执行到结束
+func (c *Cron) StopWithTimeout(d time.Duration) error {
+	ctx := c.Stop()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil
+	case <-timer.C:
+		c.logger.Error("shutdown timed out", "timeout", d)
+		return ErrShutdownTimeout
+	}
+}
+
+// StopNow 停止调度器，取消所有正在执行任务的context，并返回等待它们结束的context
+//
+// Deprecated: Stop同样会取消正在执行任务的context，StopNow与Stop完全相同，请直接使用Stop
+func (c *Cron) StopNow() context.Context {
+	return c.Stop()
+}
+
+// cancelJobContext 取消当前传给任务的上下文，并为之后启动的任务创建新的上下文
+func (c *Cron) cancelJobContext() {
+	c.ctxMu.Lock()
+	defer c.ctxMu.Unlock()
+	c.cancelJobs()
+	c.jobCtx, c.cancelJobs = context.WithCancel(context.Background())
+}
+
 // removeEntry 从任务列表中删除指定ID的任务
+// 启用了WithArchiveRemoved时被删除的任务会移入归档
+// 调用者需要持有entriesMu
+// 通过索引判断任务是否存在，原地移动后面的任务而不重新分配切片，保持其余任务的顺序
 func (c *Cron) removeEntry(id EntryID) {
-	if c.entries == nil {
+	e, ok := c.byID[id]
+	if !ok {
 		return
 	}
-	var entries []*Entry
-	for _, e := range c.entries {
-		if e.ID != id {
-			entries = append(entries, e)
+	delete(c.byID, id)
+	for i, other := range c.entries {
+		if other != e {
+			continue
 		}
+		last := len(c.entries) - 1
+		copy(c.entries[i:], c.entries[i+1:])
+		c.entries[last] = nil // 避免底层数组继续引用被删除的任务
+		c.entries = c.entries[:last]
+		break
+	}
+	if c.archive {
+		c.archiveEntry(e)
 	}
-	c.entries = entries
+}
+
+// appendEntry 将任务加入任务列表和索引
+// 调用者需要持有entriesMu
+func (c *Cron) appendEntry(e *Entry) {
+	c.entries = append(c.entries, e)
+	c.byID[e.ID] = e
+}
+
+// replaceEntry 用e替换任务列表中位置i的任务，e与原任务的ID相同
+// 调用者需要持有entriesMu
+func (c *Cron) replaceEntry(i int, e *Entry) {
+	c.entries[i] = e
+	c.byID[e.ID] = e
 }
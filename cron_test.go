@@ -1,8 +1,14 @@
 package cron
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -128,3 +134,1090 @@ type ImmediateSchedule struct{}
 func (s *ImmediateSchedule) Next(t time.Time) time.Time {
 	return t
 }
+
+// TestDrainBacklog verifies that runs queued by scheduled fires are drained within the scheduler's limits
+func TestDrainBacklog(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk), WithMaxConcurrent(3))
+
+	release := make(chan struct{})
+	var calls, current, peak, completed int32
+	inner := FuncJob(func() {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// first run blocks and holds a slot so that later fires pile up in the queue
+			<-release
+			return
+		}
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		atomic.AddInt32(&completed, 1)
+	})
+	job := NewChain(DelayIfStillRunning(&discardLogger{})).Then(inner).(*delayJob)
+	id := c.AddJob(Every(time.Minute), job)
+	c.Start()
+	defer c.Stop()
+
+	for range 7 {
+		clk.BlockUntil(1)
+		clk.Advance(time.Minute)
+	}
+	deadline := time.Now().Add(time.Second)
+	for queued(job) < 6 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := queued(job); n != 6 {
+		t.Fatalf("expected 6 queued fires, got %d", n)
+	}
+
+	c.DrainBacklog(id, 3)
+
+	if got := atomic.LoadInt32(&completed); got != 6 {
+		t.Errorf("expected 6 drained runs, got %d", got)
+	}
+	// the blocked first run keeps one of the three slots
+	if got := atomic.LoadInt32(&peak); got > 2 {
+		t.Errorf("expected drained runs to share the 2 free slots, got %d concurrent", got)
+	}
+
+	close(release)
+	c.WaitJobs()
+	if got := atomic.LoadInt32(&calls); got != 7 {
+		t.Errorf("expected 7 total runs, got %d", got)
+	}
+}
+
+// queued returns the number of fires waiting in a delayed job's queue
+func queued(j *delayJob) int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.queue)
+}
+
+// TestPauseEntry verifies that a paused entry does not fire until it is resumed
+func TestPauseEntry(t *testing.T) {
+	c := New()
+	var count int32
+	id := c.AddFunc(Every(20*time.Millisecond), func() { atomic.AddInt32(&count, 1) })
+	if !c.PauseEntry(id) {
+		t.Fatal("PauseEntry returned false for existing entry")
+	}
+	c.Start()
+	defer c.Stop()
+
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&count); got != 0 {
+		t.Errorf("paused entry fired %d times", got)
+	}
+
+	if !c.ResumeEntry(id) {
+		t.Fatal("ResumeEntry returned false for existing entry")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if atomic.LoadInt32(&count) == 0 {
+		t.Error("resumed entry did not fire")
+	}
+
+	if c.PauseEntry(EntryID(999)) || c.ResumeEntry(EntryID(999)) {
+		t.Error("expected false for unknown entry")
+	}
+}
+
+// TestStopNowCancelsContextJobs verifies that StopNow cancels the context of in-flight context jobs
+func TestStopNowCancelsContextJobs(t *testing.T) {
+	c := New()
+	started := make(chan struct{}, 1)
+	returned := make(chan struct{})
+	c.AddContextFunc(Every(10*time.Millisecond), func(ctx context.Context) {
+		select {
+		case started <- struct{}{}:
+		default:
+			return
+		}
+		select {
+		case <-ctx.Done():
+			close(returned)
+		case <-time.After(5 * time.Second):
+		}
+	})
+	c.Start()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("context job did not start")
+	}
+
+	ctx := c.StopNow()
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Fatal("context job was not cancelled by StopNow")
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Error("StopNow context was not done after jobs returned")
+	}
+}
+
+// TestStopCancelsContextJobs verifies that Stop cancels the context of running jobs while plain jobs still finish
+func TestStopCancelsContextJobs(t *testing.T) {
+	c := New()
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	ctxID := c.AddContextFunc(&TestSchedule{}, func(ctx context.Context) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			close(cancelled)
+		case <-time.After(time.Second):
+		}
+	})
+	var plain atomic.Int32
+	plainID := c.AddFunc(&TestSchedule{}, func() { plain.Add(1) })
+
+	c.RunNow(ctxID)
+	c.RunNow(plainID)
+	<-started
+	ctx := c.Stop()
+
+	select {
+	case <-cancelled:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("expected Stop to cancel the context of a running job")
+	}
+	<-ctx.Done()
+	if n := plain.Load(); n != 1 {
+		t.Errorf("expected the plain job to run once, got %d", n)
+	}
+
+	// jobs started after Stop receive a fresh context
+	fresh := make(chan error, 1)
+	id := c.AddContextFunc(&TestSchedule{}, func(ctx context.Context) { fresh <- ctx.Err() })
+	c.RunNow(id)
+	if err := <-fresh; err != nil {
+		t.Errorf("expected a live context after Stop, got %v", err)
+	}
+}
+
+// TestStopWithTimeout verifies that a job outliving the timeout makes StopWithTimeout return an error
+func TestStopWithTimeout(t *testing.T) {
+	c := New()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	id := c.AddFunc(&TestSchedule{}, func() {
+		close(started)
+		<-release
+	})
+	c.Start()
+	c.RunNow(id)
+	<-started
+
+	begin := time.Now()
+	err := c.StopWithTimeout(50 * time.Millisecond)
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Errorf("expected ErrShutdownTimeout, got %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Errorf("expected to give up after the timeout, waited %v", elapsed)
+	}
+	c.runningMu.Lock()
+	running := c.running
+	c.runningMu.Unlock()
+	if running {
+		t.Error("expected the scheduler to be stopped after a timed out shutdown")
+	}
+	close(release)
+
+	if err := c.StopWithTimeout(time.Second); err != nil {
+		t.Errorf("expected nil once jobs finished, got %v", err)
+	}
+}
+
+// TestStopFromJob verifies that a job can stop its own scheduler and the scheduler shuts down cleanly
+func TestStopFromJob(t *testing.T) {
+	c := New()
+	stopped := make(chan context.Context, 1)
+	var runs atomic.Int32
+	c.AddFunc(Every(10*time.Millisecond), func() {
+		if runs.Add(1) == 1 {
+			stopped <- c.Stop()
+		}
+	})
+	c.Start()
+
+	var ctx context.Context
+	select {
+	case ctx = <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop called from a job did not return")
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not shut down after being stopped from a job")
+	}
+	n := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	if got := runs.Load(); got != n {
+		t.Errorf("expected no runs after stopping, got %d more", got-n)
+	}
+	if err := c.StartE(); err != nil {
+		t.Errorf("expected the scheduler to be restartable, got %v", err)
+	}
+	c.Stop()
+}
+
+// TestRunNow verifies that a manual trigger runs the job while leaving its next run time untouched
+func TestRunNow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	ran := make(chan struct{}, 1)
+	id := c.AddFunc(Every(time.Hour), func() { ran <- struct{}{} })
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	before, _ := c.Entry(id)
+	if err := c.RunNow(id); err != nil {
+		t.Fatalf("RunNow returned error: %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("job was not run by RunNow")
+	}
+	c.WaitJobs()
+	after, _ := c.Entry(id)
+	if !after.Next.Equal(before.Next) || !after.Next.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected next run to stay at %v, got %v", before.Next, after.Next)
+	}
+
+	if err := c.RunNow(EntryID(999)); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("expected ErrEntryNotFound for unknown entry, got %v", err)
+	}
+}
+
+// TestConcurrentAddRemoveEntries exercises Add, Remove and Entries against an active run loop; run with -race
+func TestConcurrentAddRemoveEntries(t *testing.T) {
+	c := New()
+	c.AddFunc(Every(time.Millisecond), func() {})
+	c.Start()
+	defer c.Stop()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				id := c.AddFunc(Every(time.Millisecond), func() {})
+				for _, e := range c.Entries() {
+					_ = e.Next
+				}
+				c.Remove(id)
+			}
+		}()
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(time.Second)
+	for len(c.Entries()) != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := len(c.Entries()); n != 1 {
+		t.Errorf("expected only the original entry to remain, got %d", n)
+	}
+}
+
+// TestAddWhileRunningIsSynchronous verifies that entries added concurrently while running are scheduled when AddFunc returns
+func TestAddWhileRunningIsSynchronous(t *testing.T) {
+	c := New()
+	c.Start()
+	defer c.Stop()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 25 {
+				id := c.AddFunc(Every(time.Hour), func() {})
+				e, ok := c.Entry(id)
+				if !ok || e.Next.IsZero() {
+					t.Errorf("expected entry %d to be scheduled on return, got %+v (found %v)", id, e, ok)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	seen := map[EntryID]bool{}
+	for _, e := range c.Entries() {
+		if seen[e.ID] {
+			t.Errorf("duplicate entry ID %d", e.ID)
+		}
+		seen[e.ID] = true
+	}
+	if len(seen) != 200 {
+		t.Errorf("expected 200 entries, got %d", len(seen))
+	}
+}
+
+// TestDisableEnable verifies that a disabled entry does not run and is rescheduled from now when enabled
+func TestDisableEnable(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	var disabled, other atomic.Int32
+	id := c.AddFunc(Every(time.Minute), func() { disabled.Add(1) })
+	c.AddFunc(Every(time.Minute), func() { other.Add(1) })
+	c.Disable(id)
+	c.Disable(EntryID(999))
+	c.Start()
+	defer c.Stop()
+
+	for range 3 {
+		clk.BlockUntil(1)
+		clk.Advance(time.Minute)
+	}
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if n := disabled.Load(); n != 0 {
+		t.Errorf("expected the disabled entry not to run, got %d runs", n)
+	}
+	if n := other.Load(); n != 3 {
+		t.Errorf("expected the other entry to keep running, got %d runs", n)
+	}
+
+	c.Enable(id)
+	if e, _ := c.Entry(id); e.Paused || !e.Next.Equal(start.Add(4*time.Minute)) {
+		t.Errorf("expected an enabled entry due at %v, got %+v", start.Add(4*time.Minute), e)
+	}
+	clk.Advance(time.Minute)
+	deadline := time.Now().Add(time.Second)
+	for disabled.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := disabled.Load(); n != 1 {
+		t.Errorf("expected the entry to run once after Enable, got %d", n)
+	}
+}
+
+// TestNextRun verifies that NextRun matches the schedule and reports unknown entries
+func TestNextRun(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	schedule := Every(time.Minute)
+	id := c.AddFunc(schedule, func() {})
+	if next, ok := c.NextRun(id); !ok || !next.IsZero() {
+		t.Errorf("expected a known entry without next run before start, got %v (found %v)", next, ok)
+	}
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	if next, ok := c.NextRun(id); !ok || !next.Equal(schedule.Next(start)) {
+		t.Errorf("expected %v, got %v (found %v)", schedule.Next(start), next, ok)
+	}
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	if next, _ := c.NextRun(id); !next.Equal(schedule.Next(start.Add(time.Minute))) {
+		t.Errorf("expected the next run to advance to %v, got %v", schedule.Next(start.Add(time.Minute)), next)
+	}
+	if next, ok := c.NextRun(EntryID(999)); ok || !next.IsZero() {
+		t.Errorf("expected zero time and false for unknown entry, got %v (found %v)", next, ok)
+	}
+}
+
+// TestRemoveAll verifies that clearing a running scheduler leaves no entries and nothing fires afterwards
+func TestRemoveAll(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk), WithArchiveRemoved())
+	var runs atomic.Int32
+	for range 5 {
+		c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	}
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	c.RemoveAll()
+	if n := len(c.Entries()); n != 0 {
+		t.Errorf("expected no entries after RemoveAll, got %d", n)
+	}
+	if n := len(c.Archived()); n != 5 {
+		t.Errorf("expected removed entries to be archived, got %d", n)
+	}
+	for range 3 {
+		clk.Advance(time.Minute)
+		time.Sleep(5 * time.Millisecond)
+	}
+	c.WaitJobs()
+	if n := runs.Load(); n != 0 {
+		t.Errorf("expected no runs after RemoveAll, got %d", n)
+	}
+
+	c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected the scheduler to keep working after RemoveAll, got %d runs", n)
+	}
+	c.RemoveAll()
+}
+
+// TestReschedule verifies that swapping the schedule keeps the ID and history and takes effect immediately
+func TestReschedule(t *testing.T) {
+	c := New()
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Hour), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	c.RunNow(id)
+	c.WaitJobs()
+	before, _ := c.Entry(id)
+
+	if err := c.Reschedule(id, Every(100*time.Millisecond)); err != nil {
+		t.Fatalf("Reschedule returned error: %v", err)
+	}
+	after, ok := c.Entry(id)
+	if !ok || !after.Prev.Equal(before.Prev) {
+		t.Errorf("expected entry %d to keep its history, got %+v (found %v)", id, after, ok)
+	}
+	if d := time.Until(after.Next); d > 100*time.Millisecond {
+		t.Errorf("expected next run within 100ms after rescheduling, got %v", d)
+	}
+
+	time.Sleep(350 * time.Millisecond)
+	if n := runs.Load(); n < 3 {
+		t.Errorf("expected the faster cadence to take effect, got %d runs", n)
+	}
+	if err := c.Reschedule(EntryID(999), Every(time.Second)); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("expected ErrEntryNotFound for unknown entry, got %v", err)
+	}
+}
+
+// TestAddNilArguments verifies that nil schedules and jobs are rejected up front
+func TestAddNilArguments(t *testing.T) {
+	c := New()
+	var nilFunc func()
+	var nilJob Job
+
+	if _, err := c.AddJobE(nil, FuncJob(func() {})); !errors.Is(err, ErrNilSchedule) {
+		t.Errorf("expected ErrNilSchedule, got %v", err)
+	}
+	if _, err := c.AddJobE(Every(time.Second), nilJob); !errors.Is(err, ErrNilJob) {
+		t.Errorf("expected ErrNilJob for nil job, got %v", err)
+	}
+	if _, err := c.AddFuncE(Every(time.Second), nilFunc); !errors.Is(err, ErrNilJob) {
+		t.Errorf("expected ErrNilJob for nil func, got %v", err)
+	}
+	if _, err := c.AddCron("* * * * *", nilFunc); !errors.Is(err, ErrNilJob) {
+		t.Errorf("expected ErrNilJob from AddCron, got %v", err)
+	}
+	if id := c.AddFunc(nil, func() {}); id != 0 {
+		t.Errorf("expected AddFunc to return 0 for nil schedule, got %d", id)
+	}
+	if id := c.AddJob(Every(time.Second), nilJob); id != 0 {
+		t.Errorf("expected AddJob to return 0 for nil job, got %d", id)
+	}
+	var nilContextJob ContextJob
+	var nilAdaptiveFunc AdaptiveFuncJob
+	for name, add := range map[string]func() EntryID{
+		"AddErrorFunc":   func() EntryID { return c.AddErrorFunc(Every(time.Second), nil) },
+		"AddErrorJob":    func() EntryID { return c.AddErrorJob(Every(time.Second), nil) },
+		"AddContextFunc": func() EntryID { return c.AddContextFunc(Every(time.Second), nil) },
+		"AddContextJob":  func() EntryID { return c.AddContextJob(Every(time.Second), nilContextJob) },
+		"AddAdaptiveJob": func() EntryID { return c.AddAdaptiveJob(Every(time.Second), nilAdaptiveFunc) },
+		"AddTypedJob":    func() EntryID { return AddTypedJob[int](c, Every(time.Second), nil, nil) },
+		"AddStringJob":   func() EntryID { return c.AddStringJob(Every(time.Second), nil) },
+		"AddStringFunc":  func() EntryID { return c.AddStringJob(Every(time.Second), StringFuncJob(nil)) },
+		"AddFuncWithCompensation": func() EntryID {
+			return c.AddFuncWithCompensation(Every(time.Second), nil, func() {})
+		},
+		"AddFuncWithCompensation undo": func() EntryID {
+			return c.AddFuncWithCompensation(Every(time.Second), func() error { return nil }, nil)
+		},
+		"AddStaged": func() EntryID {
+			return c.AddStaged(Every(time.Second), [][]Job{{FuncJob(func() {})}, {nilJob}})
+		},
+	} {
+		if id := add(); id != 0 {
+			t.Errorf("expected %s to reject a nil job, got id %d", name, id)
+		}
+	}
+	if n := len(c.Entries()); n != 0 {
+		t.Errorf("expected no entries, got %d", n)
+	}
+
+	id, err := c.AddFuncE(Every(time.Second), func() {})
+	if err != nil || id == 0 {
+		t.Fatalf("expected valid entry to be added, got id %d err %v", id, err)
+	}
+	if err := c.Reschedule(id, nil); !errors.Is(err, ErrNilSchedule) {
+		t.Errorf("expected Reschedule to reject nil schedule, got %v", err)
+	}
+}
+
+// TestTimeUntilNext verifies that the earliest active entry determines the wait
+func TestTimeUntilNext(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	c.AddFunc(Every(time.Hour), func() {})
+	soonest := c.AddFunc(Every(10*time.Minute), func() {})
+	c.AddFunc(Every(30*time.Minute), func() {})
+	if _, ok := c.TimeUntilNext(); ok {
+		t.Error("expected no next time before the scheduler starts")
+	}
+
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+	if d, ok := c.TimeUntilNext(); !ok || d != 10*time.Minute {
+		t.Errorf("expected 10m until next, got %v %v", d, ok)
+	}
+
+	clk.Advance(4 * time.Minute)
+	if d, ok := c.TimeUntilNext(); !ok || d != 6*time.Minute {
+		t.Errorf("expected 6m until next, got %v %v", d, ok)
+	}
+
+	c.PauseEntry(soonest)
+	if d, ok := c.TimeUntilNext(); !ok || d != 26*time.Minute {
+		t.Errorf("expected paused entry to be ignored, got %v %v", d, ok)
+	}
+
+	c.RemoveAll()
+	if _, ok := c.TimeUntilNext(); ok {
+		t.Error("expected no next time without entries")
+	}
+
+	c.AddFunc(Every(time.Hour), func() {})
+	if _, ok := c.TimeUntilNext(); !ok {
+		t.Error("expected a next time for an entry added while running")
+	}
+	c.Stop()
+	if _, ok := c.TimeUntilNext(); ok {
+		t.Error("expected no next time after the scheduler stops")
+	}
+}
+
+// TestStartAddRace verifies that entries added while the scheduler starts are scheduled exactly once
+func TestStartAddRace(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for range 20 {
+		clk := NewFakeClock(start)
+		c := New(WithClock(clk), WithEventBuffer(100))
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.AddJob(Every(time.Minute), FuncJob(func() {}))
+			}()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Start()
+		}()
+		wg.Wait()
+		clk.BlockUntil(1)
+
+		entries := c.Entries()
+		if len(entries) != 10 {
+			t.Fatalf("expected 10 entries, got %d", len(entries))
+		}
+		for _, e := range entries {
+			if want := start.Add(time.Minute); !e.Next.Equal(want) {
+				t.Errorf("entry %d: expected next %v, got %v", e.ID, want, e.Next)
+			}
+		}
+		scheduled := make(map[EntryID]int)
+		for _, ev := range c.RecentEvents(0) {
+			if !ev.Next.IsZero() {
+				scheduled[ev.EntryID]++
+			}
+		}
+		for _, e := range entries {
+			if scheduled[e.ID] != 1 {
+				t.Errorf("entry %d: expected to be scheduled once, got %d", e.ID, scheduled[e.ID])
+			}
+		}
+		c.Stop()
+	}
+}
+
+// TestLen verifies that the count follows Add and Remove calls while running
+func TestLen(t *testing.T) {
+	c := New()
+	if n := c.Len(); n != 0 {
+		t.Errorf("expected 0 entries, got %d", n)
+	}
+	first := c.AddFunc(Every(time.Hour), func() {})
+	c.Start()
+	defer c.Stop()
+
+	var ids []EntryID
+	for i := range 5 {
+		ids = append(ids, c.AddFunc(Every(time.Hour), func() {}))
+		if n := c.Len(); n != i+2 {
+			t.Errorf("expected %d entries after add, got %d", i+2, n)
+		}
+	}
+	c.PauseEntry(first)
+	if n := c.Len(); n != 6 {
+		t.Errorf("expected paused entries to be counted, got %d", n)
+	}
+	for i, id := range ids {
+		c.Remove(id)
+		if n := c.Len(); n != 5-i {
+			t.Errorf("expected %d entries after remove, got %d", 5-i, n)
+		}
+	}
+	c.Remove(EntryID(999))
+	if n := c.Len(); n != 1 {
+		t.Errorf("expected removing an unknown entry to leave 1, got %d", n)
+	}
+}
+
+// TestPauseUntil verifies that an entry paused until a point in time resumes automatically
+func TestPauseUntil(t *testing.T) {
+	c := New()
+	var count int32
+	id := c.AddFunc(Every(20*time.Millisecond), func() { atomic.AddInt32(&count, 1) })
+	c.Start()
+	defer c.Stop()
+
+	if !c.PauseUntil(id, time.Now().Add(150*time.Millisecond)) {
+		t.Fatal("PauseUntil returned false for existing entry")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&count); got != 0 {
+		t.Errorf("paused entry fired %d times", got)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if atomic.LoadInt32(&count) == 0 {
+		t.Error("entry did not resume after the pause ended")
+	}
+	if e, _ := c.Entry(id); e.Paused || !e.PausedUntil.IsZero() {
+		t.Errorf("expected entry to be resumed, got paused=%v until=%v", e.Paused, e.PausedUntil)
+	}
+
+	if c.PauseUntil(EntryID(999), time.Now()) {
+		t.Error("expected false for unknown entry")
+	}
+}
+
+// TestRemoveBeforeFireOnSameWake verifies that a removal pending when an entry becomes due wins over firing it
+func TestRemoveBeforeFireOnSameWake(t *testing.T) {
+	c := New()
+
+	// the first entry keeps the run loop busy inside Next until the second entry is overdue
+	release := make(chan struct{})
+	c.AddFunc(&blockingSchedule{delay: 20 * time.Millisecond, release: release}, func() {})
+
+	open := make(chan struct{})
+	close(open)
+	var fired int32
+	id := c.AddFunc(&blockingSchedule{delay: 40 * time.Millisecond, release: open}, func() {
+		atomic.StoreInt32(&fired, 1)
+	})
+
+	c.Start()
+	defer c.Stop()
+
+	time.Sleep(80 * time.Millisecond)
+	removed := make(chan struct{})
+	go func() {
+		c.Remove(id)
+		close(removed)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	<-removed
+
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&fired) != 0 {
+		t.Error("removed entry fired on the wake it was removed")
+	}
+	if _, ok := c.Entry(id); ok {
+		t.Error("entry was not removed")
+	}
+}
+
+// blockingSchedule fires once after delay, then blocks in Next until release is closed
+type blockingSchedule struct {
+	delay   time.Duration
+	release chan struct{}
+	calls   int32
+}
+
+func (s *blockingSchedule) Next(t time.Time) time.Time {
+	if atomic.AddInt32(&s.calls, 1) == 1 {
+		return t.Add(s.delay)
+	}
+	<-s.release
+	return t.Add(time.Hour)
+}
+
+// TestEntriesDeterministicOrder verifies that Entries sorts by Next and then by ID
+func TestEntriesDeterministicOrder(t *testing.T) {
+	c := New()
+	var ids []EntryID
+	for i := 0; i < 5; i++ {
+		ids = append(ids, c.AddFunc(&TestSchedule{}, func() {}))
+	}
+
+	// give every entry the same Next and shuffle the internal order
+	next := time.Now().Add(time.Hour)
+	c.entriesMu.Lock()
+	for _, e := range c.entries {
+		e.Next = next
+	}
+	c.entries[0], c.entries[4] = c.entries[4], c.entries[0]
+	c.entries[1], c.entries[3] = c.entries[3], c.entries[1]
+	c.entriesMu.Unlock()
+
+	earlier := c.AddFunc(&TestSchedule{}, func() {})
+	c.entriesMu.Lock()
+	c.entry(earlier).Next = next.Add(-time.Minute)
+	c.entriesMu.Unlock()
+	unscheduled := c.AddFunc(&TestSchedule{}, func() {})
+
+	expected := append([]EntryID{earlier}, append(ids, unscheduled)...)
+	for round := 0; round < 3; round++ {
+		var got []EntryID
+		for _, e := range c.Entries() {
+			got = append(got, e.ID)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatalf("round %d: expected order %v, got %v", round, expected, got)
+		}
+	}
+}
+
+// TestExclusiveEntry verifies that an exclusive job blocks other jobs while normal jobs overlap each other
+func TestExclusiveEntry(t *testing.T) {
+	c := New()
+	var mu sync.Mutex
+	var exclusiveEnd time.Time
+	var starts []time.Time
+	var current, peak int32
+
+	exclusive := c.AddFunc(&TestSchedule{}, func() {
+		time.Sleep(100 * time.Millisecond)
+		mu.Lock()
+		exclusiveEnd = time.Now()
+		mu.Unlock()
+	}, WithExclusive())
+	normal := func() {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+	}
+	a := c.AddFunc(&TestSchedule{}, normal)
+	b := c.AddFunc(&TestSchedule{}, normal)
+
+	c.RunNow(exclusive)
+	time.Sleep(20 * time.Millisecond)
+	c.RunNow(a)
+	c.RunNow(b)
+	<-c.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(starts) != 2 {
+		t.Fatalf("expected 2 normal runs, got %d", len(starts))
+	}
+	for _, start := range starts {
+		if start.Before(exclusiveEnd) {
+			t.Errorf("normal job started at %v before exclusive job finished at %v", start, exclusiveEnd)
+		}
+	}
+	if atomic.LoadInt32(&peak) != 2 {
+		t.Errorf("expected normal jobs to overlap, peak concurrency was %d", peak)
+	}
+}
+
+// TestWakeNowLeader verifies that suppressed jobs start firing promptly after gaining leadership
+func TestWakeNowLeader(t *testing.T) {
+	var leader atomic.Bool
+	c := New(WithLeaderCheck(leader.Load))
+	var runs atomic.Int32
+	c.AddFunc(Every(10*time.Millisecond), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("expected no runs while not leader, got %d", got)
+	}
+
+	leader.Store(true)
+	c.WakeNow()
+	time.Sleep(20 * time.Millisecond)
+	if runs.Load() == 0 {
+		t.Error("expected job to fire promptly after gaining leadership")
+	}
+}
+
+// TestWakeNowNotDue verifies that waking the loop does not fire jobs that are not yet due
+func TestWakeNowNotDue(t *testing.T) {
+	c := New()
+	var runs atomic.Int32
+	c.AddFunc(&TestSchedule{}, func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	for range 5 {
+		c.WakeNow()
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := runs.Load(); got != 0 {
+		t.Errorf("expected no runs, got %d", got)
+	}
+}
+
+// TestStartE verifies that a second StartE reports that the scheduler is already running
+func TestStartE(t *testing.T) {
+	c := New()
+	if err := c.StartE(); err != nil {
+		t.Fatalf("unexpected error on first start: %v", err)
+	}
+	defer c.Stop()
+	if err := c.StartE(); !errors.Is(err, ErrSchedulerRunning) {
+		t.Errorf("expected ErrSchedulerRunning, got %v", err)
+	}
+	c.Start()
+}
+
+// TestEntriesInOrder verifies that entries are returned by ID regardless of their next fire times
+func TestEntriesInOrder(t *testing.T) {
+	c := New()
+	c.AddFunc(Every(3*time.Hour), func() {})
+	c.AddFunc(Every(time.Hour), func() {})
+	c.AddFunc(Every(2*time.Hour), func() {})
+	c.Start()
+	defer c.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	var ids []EntryID
+	for _, e := range c.EntriesInOrder() {
+		ids = append(ids, e.ID)
+	}
+	if want := []EntryID{1, 2, 3}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("expected IDs %v, got %v", want, ids)
+	}
+	if first := c.Entries()[0].ID; first != 2 {
+		t.Errorf("expected Entries to be sorted by next time with entry 2 first, got %d", first)
+	}
+}
+
+// TestResumeRunIfMissed verifies that an overdue entry fires immediately when resumed with RunIfMissed
+func TestResumeRunIfMissed(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	ran := make(chan time.Time, 4)
+	missed := c.AddFunc(Every(time.Hour), func() { ran <- clk.Now() })
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	c.PauseEntry(missed)
+	clk.Advance(90 * time.Minute)
+
+	c.ResumeEntry(missed, ResumeOptions{RunIfMissed: true})
+	select {
+	case at := <-ran:
+		if want := time.Date(2024, 1, 1, 1, 30, 0, 0, time.UTC); !at.Equal(want) {
+			t.Errorf("expected immediate fire at %v, got %v", want, at)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("missed run was not fired on resume")
+	}
+
+	clk.BlockUntil(1)
+	e, _ := c.Entry(missed)
+	if want := time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC); !e.Next.Equal(want) {
+		t.Errorf("expected next %v after the make-up run, got %v", want, e.Next)
+	}
+}
+
+// TestResumeWithoutRunIfMissed verifies that resuming normally waits for the next cycle
+func TestResumeWithoutRunIfMissed(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Hour), func() { runs.Add(1) })
+	c.Start()
+	clk.BlockUntil(1)
+
+	c.PauseEntry(id)
+	clk.Advance(90 * time.Minute)
+	c.ResumeEntry(id)
+	time.Sleep(10 * time.Millisecond)
+	<-c.Stop().Done()
+
+	if got := runs.Load(); got != 0 {
+		t.Errorf("expected no runs, got %d", got)
+	}
+}
+
+// TestEntriesLiveSnapshot verifies that Entries can be read concurrently and reflects live values
+func TestEntriesLiveSnapshot(t *testing.T) {
+	c := New()
+	id := c.AddFunc(Every(10*time.Millisecond), func() {})
+	c.Start()
+	defer c.Stop()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				for _, e := range c.Entries() {
+					e.Next = time.Time{}
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries := c.Entries()
+	if len(entries) != 1 || entries[0].ID != id {
+		t.Fatalf("expected a single entry %d, got %+v", id, entries)
+	}
+	e := entries[0]
+	if e.Prev.IsZero() {
+		t.Error("expected prev to reflect runs that already happened")
+	}
+	if !e.Next.After(e.Prev) {
+		t.Errorf("expected next %v after prev %v", e.Next, e.Prev)
+	}
+}
+
+// TestEntryLookup verifies lookups of present and absent entry IDs
+func TestEntryLookup(t *testing.T) {
+	c := New()
+	first := c.AddFunc(Every(time.Hour), func() {})
+	second := c.AddFunc(Every(2*time.Hour), func() {})
+	c.Start()
+	defer c.Stop()
+	time.Sleep(10 * time.Millisecond)
+
+	for _, tc := range []struct {
+		id    EntryID
+		delay time.Duration
+	}{{first, time.Hour}, {second, 2 * time.Hour}} {
+		e, ok := c.Entry(tc.id)
+		if !ok {
+			t.Fatalf("expected entry %d to exist", tc.id)
+		}
+		if e.ID != tc.id || e.Schedule != Every(tc.delay) {
+			t.Errorf("unexpected entry for ID %d: %+v", tc.id, e)
+		}
+		if until := time.Until(e.Next); until <= 0 || until > tc.delay {
+			t.Errorf("expected entry %d next within %v, got %v", tc.id, tc.delay, e.Next)
+		}
+	}
+	if _, ok := c.Entry(second + 1); ok {
+		t.Error("expected absent entry lookup to fail")
+	}
+}
+
+// TestStopReleasesGoroutines verifies that every goroutine started by the scheduler has exited once Stop's context is done
+func TestStopReleasesGoroutines(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ran := make(chan struct{}, 1)
+	c := New(WithNextChangeCallback(func(time.Time, EntryID) {}))
+	id := c.AddFunc(&TestSchedule{}, func() { ran <- struct{}{} })
+	c.AddFunc(Every(time.Hour), func() {})
+	c.Start()
+	c.SuspendWhile(context.Background())
+	if err := c.RunNow(id); err != nil {
+		t.Fatalf("RunNow returned error: %v", err)
+	}
+	<-ran
+	<-c.Stop().Done()
+
+	// Stop's own waiter goroutine returns right after cancelling the context
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		buf := make([]byte, 1<<16)
+		t.Errorf("expected at most %d goroutines after stop, got %d\n%s", before, after, buf[:runtime.Stack(buf, true)])
+	}
+}
+
+// BenchmarkRemoveEntry compares removal cost with 10k entries under churn between
+// rebuilding the slice on every removal, as removeEntry used to, and the current in-place removal
+func BenchmarkRemoveEntry(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		remove func(c *Cron, id EntryID)
+	}{
+		{"rebuild", removeEntryRebuild},
+		{"in-place", (*Cron).Remove},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			c := New()
+			ids := make([]EntryID, 0, 10000)
+			for range 10000 {
+				ids = append(ids, c.AddFunc(Every(time.Minute), func() {}))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// remove the oldest entry and add a new one to keep the size constant
+				bc.remove(c, ids[i%len(ids)])
+				ids[i%len(ids)] = c.AddFunc(Every(time.Minute), func() {})
+			}
+		})
+	}
+}
+
+// removeEntryRebuild is the previous removeEntry, which allocated a new slice on every removal
+func removeEntryRebuild(c *Cron, id EntryID) {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	var entries []*Entry
+	for _, e := range c.entries {
+		if e.ID != id {
+			entries = append(entries, e)
+		}
+	}
+	c.entries = entries
+	delete(c.byID, id)
+}
@@ -0,0 +1,79 @@
+// Package crontest 提供测试使用cron调度器的代码时的辅助工具
+package crontest
+
+import (
+	"time"
+
+	"github.com/notes-bin/cron"
+)
+
+// Harness 包装一个使用FakeClock的调度器，可以确定地推进时间
+// 使用示例:
+//
+//	h := crontest.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	defer h.Close()
+//	h.Cron.AddFunc(cron.Every(time.Minute), job)
+//	h.Advance(5 * time.Minute) // job恰好执行5次，返回时均已结束
+type Harness struct {
+	Cron  *cron.Cron      // 被测试的调度器，已经启动
+	Clock *cron.FakeClock // 调度器使用的时钟
+}
+
+// New 创建一个当前时间为start的Harness并启动其调度器
+// opts会在WithClock之后应用，不应再设置时钟
+func New(start time.Time, opts ...cron.Option) *Harness {
+	clk := cron.NewFakeClock(start)
+	c := cron.New(append([]cron.Option{cron.WithClock(clk)}, opts...)...)
+	c.Start()
+	h := &Harness{Cron: c, Clock: clk}
+	h.settle()
+	return h
+}
+
+// Now 返回当前的模拟时间
+func (h *Harness) Now() time.Time {
+	return h.Clock.Now()
+}
+
+// Advance 将时间推进d，按时间顺序依次触发期间到期的所有任务
+// 每个到期时间点的任务执行结束后才会继续推进，返回时所有已触发的任务都已结束
+func (h *Harness) Advance(d time.Duration) {
+	h.settle()
+	target := h.Clock.Now().Add(d)
+	for {
+		next, ok := h.nextDue()
+		// 到期但未被触发的任务(例如调度器处于全局暂停)不会推动时间前进
+		if !ok || next.After(target) || !next.After(h.Clock.Now()) {
+			break
+		}
+		h.Clock.Set(next)
+		h.settle()
+	}
+	h.Clock.Set(target)
+	h.settle()
+}
+
+// Close 停止调度器并等待所有任务结束
+func (h *Harness) Close() {
+	<-h.Cron.Stop().Done()
+}
+
+// settle 等待主循环重新进入等待状态，并等待已触发的任务结束
+func (h *Harness) settle() {
+	h.Clock.BlockUntil(1)
+	h.Cron.WaitJobs()
+}
+
+// nextDue 返回最早的下次执行时间，没有待执行的任务时第二个返回值为false
+func (h *Harness) nextDue() (time.Time, bool) {
+	var next time.Time
+	for _, e := range h.Cron.Entries() {
+		if e.Paused || e.Next.IsZero() {
+			continue
+		}
+		if next.IsZero() || e.Next.Before(next) {
+			next = e.Next
+		}
+	}
+	return next, !next.IsZero()
+}
@@ -0,0 +1,42 @@
+package crontest
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/notes-bin/cron"
+)
+
+// TestHarnessAdvance verifies exact run counts when stepping past several intervals
+func TestHarnessAdvance(t *testing.T) {
+	h := New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer h.Close()
+
+	var minutely, everyTwo, slow atomic.Int32
+	h.Cron.AddFunc(cron.Every(time.Minute), func() { minutely.Add(1) })
+	h.Cron.AddFunc(cron.Every(2*time.Minute), func() { everyTwo.Add(1) })
+	h.Cron.AddFunc(cron.Every(90*time.Second), func() {
+		time.Sleep(10 * time.Millisecond)
+		slow.Add(1)
+	})
+
+	h.Advance(5*time.Minute + 30*time.Second)
+	if got := minutely.Load(); got != 5 {
+		t.Errorf("expected 5 minutely runs, got %d", got)
+	}
+	if got := everyTwo.Load(); got != 2 {
+		t.Errorf("expected 2 two-minute runs, got %d", got)
+	}
+	if got := slow.Load(); got != 3 {
+		t.Errorf("expected 3 completed slow runs, got %d", got)
+	}
+
+	h.Advance(30 * time.Second)
+	if got := minutely.Load(); got != 6 {
+		t.Errorf("expected 6 minutely runs, got %d", got)
+	}
+	if want := time.Date(2024, 1, 1, 0, 6, 0, 0, time.UTC); !h.Now().Equal(want) {
+		t.Errorf("expected time %v, got %v", want, h.Now())
+	}
+}
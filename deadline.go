@@ -0,0 +1,27 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// watchDeadline 为一次执行设置截止时间并监视其是否按时结束
+// 返回派生的ctx和执行结束后必须调用的stop函数
+// 到达截止时间时取消ctx并记录Error日志；再经过与截止时间等长的宽限期仍未结束时，
+// 认为任务忽略了取消，累加Stats().LeakedJobs并再次记录Error日志
+func (c *Cron) watchDeadline(ctx context.Context, id EntryID) (context.Context, func()) {
+	d := c.hardDeadline
+	ctx, cancel := context.WithTimeout(ctx, d)
+	expired := time.AfterFunc(d, func() {
+		c.logger.Error("job exceeded hard deadline, context cancelled", "entry", id, "deadline", d)
+	})
+	leaked := time.AfterFunc(2*d, func() {
+		c.leakedJobs.Add(1)
+		c.logger.Error("job ignored cancellation and is still running, goroutine leaked", "entry", id, "deadline", d, "grace", d)
+	})
+	return ctx, func() {
+		expired.Stop()
+		leaked.Stop()
+		cancel()
+	}
+}
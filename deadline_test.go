@@ -0,0 +1,46 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestHardDeadlineLeak verifies that a job ignoring cancellation is counted as leaked after the grace period
+func TestHardDeadlineLeak(t *testing.T) {
+	c := New(WithHardDeadlineLog(30 * time.Millisecond))
+	id := c.AddFunc(&TestSchedule{}, func() { time.Sleep(150 * time.Millisecond) })
+
+	c.RunNow(id)
+	time.Sleep(45 * time.Millisecond)
+	if got := c.Stats().LeakedJobs; got != 0 {
+		t.Errorf("expected no leaked jobs before the grace period ends, got %d", got)
+	}
+	time.Sleep(55 * time.Millisecond)
+	if got := c.Stats().LeakedJobs; got != 1 {
+		t.Errorf("expected 1 leaked job after the grace period, got %d", got)
+	}
+	<-c.Stop().Done()
+}
+
+// TestHardDeadlineCooperative verifies that a job honouring cancellation is not counted as leaked
+func TestHardDeadlineCooperative(t *testing.T) {
+	c := New(WithHardDeadlineLog(30 * time.Millisecond))
+	cancelled := make(chan struct{})
+	id := c.AddContextFunc(&TestSchedule{}, func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+	})
+
+	c.RunNow(id)
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("job context was not cancelled at the deadline")
+	}
+	<-c.Stop().Done()
+	time.Sleep(60 * time.Millisecond)
+	if got := c.Stats().LeakedJobs; got != 0 {
+		t.Errorf("expected no leaked jobs, got %d", got)
+	}
+}
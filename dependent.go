@@ -0,0 +1,46 @@
+package cron
+
+import "time"
+
+// dependentSchedule 是依赖任务的调度器，永远不会主动触发
+// 依赖任务只在父任务成功完成后被触发
+type dependentSchedule struct{}
+
+// Next 返回零值，依赖任务不参与定时调度
+func (dependentSchedule) Next(time.Time) time.Time {
+	return time.Time{}
+}
+
+// AddDependent 添加一个依赖任务，它没有自己的调度，只在父任务每次成功完成后执行一次
+// 父任务返回错误(ErrorJob)或panic时不会触发依赖任务
+// 新任务还没有子任务，因此不会与父任务形成依赖环
+// 如果父任务不存在，返回ErrEntryNotFound
+func (c *Cron) AddDependent(parent EntryID, cmd Job, opts ...EntryOption) (EntryID, error) {
+	if _, ok := c.Entry(parent); !ok {
+		return 0, ErrEntryNotFound
+	}
+	return c.addEntry(&Entry{
+		Schedule: dependentSchedule{},
+		Job:      cmd,
+		parent:   parent,
+	}, opts...)
+}
+
+// startDependents 触发所有依赖指定父任务且未暂停的子任务
+func (c *Cron) startDependents(parent EntryID) {
+	var children []*Entry
+	c.entriesMu.Lock()
+	now := c.now()
+	for _, e := range c.entries {
+		if e.parent == parent && !e.Paused {
+			e.Prev = now
+			children = append(children, e)
+		}
+	}
+	c.entriesMu.Unlock()
+
+	for _, e := range children {
+		c.logger.Info("run dependent", "now", now, "entry", e.ID, "parent", parent)
+		c.startJob(e)
+	}
+}
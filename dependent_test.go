@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAddDependent verifies that a dependent job runs once per successful parent run and not on failure
+func TestAddDependent(t *testing.T) {
+	c := New()
+	var parentRuns, childRuns int32
+	parent := c.AddErrorFunc(Every(time.Hour), func() error {
+		// every second run fails
+		if atomic.AddInt32(&parentRuns, 1)%2 == 0 {
+			return errors.New("parent failed")
+		}
+		return nil
+	})
+	if _, err := c.AddDependent(parent, FuncJob(func() {
+		atomic.AddInt32(&childRuns, 1)
+	})); err != nil {
+		t.Fatalf("AddDependent returned error: %v", err)
+	}
+	c.Start()
+	defer c.Stop()
+
+	for i := 0; i < 4; i++ {
+		if err := c.RunNow(parent); err != nil {
+			t.Fatalf("RunNow returned error: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if got := atomic.LoadInt32(&parentRuns); got != 4 {
+		t.Fatalf("expected 4 parent runs, got %d", got)
+	}
+	if got := atomic.LoadInt32(&childRuns); got != 2 {
+		t.Errorf("expected 2 dependent runs, got %d", got)
+	}
+}
+
+// TestAddDependentPanic verifies that a panicking parent does not trigger its dependents
+func TestAddDependentPanic(t *testing.T) {
+	c := New()
+	parent := c.AddFunc(Every(time.Hour), func() { panic("boom") })
+	var childRuns int32
+	c.AddDependent(parent, FuncJob(func() { atomic.AddInt32(&childRuns, 1) }))
+
+	c.RunNow(parent)
+	ctx := c.Stop()
+	<-ctx.Done()
+
+	if got := atomic.LoadInt32(&childRuns); got != 0 {
+		t.Errorf("expected no dependent runs after parent panic, got %d", got)
+	}
+}
+
+// TestAddDependentUnknownParent verifies that an unknown parent is rejected
+func TestAddDependentUnknownParent(t *testing.T) {
+	c := New()
+	if _, err := c.AddDependent(EntryID(42), FuncJob(func() {})); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("expected ErrEntryNotFound, got %v", err)
+	}
+}
@@ -6,4 +6,13 @@
 //   - 完整时区支持，可按指定时区执行任务
 //   - 灵活的调度策略接口，支持复杂定时需求
 //   - 任务panic安全捕获，避免单个任务崩溃影响整体调度
+//
+// 触发精度:
+//
+// 主循环只为最早到期的任务创建一个定时器，没有任务时使用很长的空闲定时器，不会忙等。
+// 定时器总是按醒来时的当前时间计算等待时长，处理到期任务耗时较长时下一次触发不会因此推迟；
+// 固定间隔的调度器(Every)从实际触发时间开始计算下次执行时间，
+// 因此定时器的唤醒延迟会累积为少量漂移，但不会丢失触发。
+// 在常见的系统上10ms的间隔可以稳定触发，更短的间隔会在添加任务时记录日志，
+// 下限可通过WithIntervalFloor调整
 package cron
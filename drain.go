@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Drain 平滑停止调度器: 立即停止安排新的触发并拒绝添加任务，
+// 已到期但主循环尚未触发的任务各执行一次，然后等待所有正在执行的任务完成
+// ctx在任务完成前结束时返回同时包装ErrShutdownTimeout和ctx.Err()的错误，未完成的任务继续在后台执行
+// 调用之后添加任务返回ErrDraining，直到再次调用Start
+// 适合滚动发布: 先停止接收新的触发，再让已经到期的工作完成后退出进程
+func (c *Cron) Drain(ctx context.Context) error {
+	c.runningMu.Lock()
+	c.draining = true
+	c.halt()
+	c.runningMu.Unlock()
+	c.logger.Info("draining")
+
+	done := make(chan struct{})
+	go func() {
+		// 主循环退出后才能确定哪些到期任务还没有被触发
+		c.loopWaiter.Wait()
+		c.runDue()
+		c.jobWaiter.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		c.logger.Error("drain timed out", "error", ctx.Err())
+		return fmt.Errorf("%w: %w", ErrShutdownTimeout, ctx.Err())
+	}
+}
+
+// runDue 将已到期但尚未触发的任务各执行一次，跳过条件与主循环相同
+// 执行后任务没有下次执行时间，再次Start时会重新计算
+func (c *Cron) runDue() {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if c.suspended > 0 || !c.leading() {
+		return
+	}
+	now := c.now()
+	for _, e := range c.entries {
+		if !e.active() || e.Next.After(now) || !c.ownsShard(e) || e.exhausted() {
+			continue
+		}
+		due := e.Next
+		c.startJobAt(e, due, c.countRun(e))
+		c.recordFire(e, now)
+		c.logEntry("drain", e, "now", now, "entry", e.ID, "due", due)
+		c.record(EventFired, e.ID, now, due)
+		e.Prev = due
+		e.Next = time.Time{}
+	}
+}
@@ -0,0 +1,86 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// skewClock reports a time ahead of its fake clock without firing any timers
+type skewClock struct {
+	*FakeClock
+	skew atomic.Int64
+}
+
+func (s *skewClock) Now() time.Time {
+	return s.FakeClock.Now().Add(time.Duration(s.skew.Load()))
+}
+
+// TestDrain verifies that due jobs run to completion while future ones never start
+func TestDrain(t *testing.T) {
+	clk := &skewClock{FakeClock: NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}
+	c := New(WithClock(clk))
+	release := make(chan struct{})
+	var due, future atomic.Int32
+	c.AddFunc(Every(time.Minute), func() {
+		<-release
+		due.Add(1)
+	})
+	c.AddFunc(Every(time.Hour), func() { future.Add(1) })
+	c.Start()
+	clk.BlockUntil(1)
+
+	// the first entry is now past due but its timer has not fired
+	clk.skew.Store(int64(2 * time.Minute))
+	drained := make(chan error, 1)
+	go func() { drained <- c.Drain(context.Background()) }()
+
+	select {
+	case err := <-drained:
+		t.Fatalf("Drain returned before the due job finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if _, err := c.AddFuncE(Every(time.Minute), func() {}); !errors.Is(err, ErrDraining) {
+		t.Errorf("expected ErrDraining while draining, got %v", err)
+	}
+	configs := []NamedSchedule{{Name: "late", Schedule: Every(time.Minute), Job: FuncJob(func() {})}}
+	if _, err := c.ApplyConfig(configs); !errors.Is(err, ErrDraining) {
+		t.Errorf("expected ApplyConfig to return ErrDraining while draining, got %v", err)
+	}
+
+	close(release)
+	if err := <-drained; err != nil {
+		t.Fatalf("unexpected drain error: %v", err)
+	}
+	if n := due.Load(); n != 1 {
+		t.Errorf("expected due job to run once, ran %d times", n)
+	}
+	if n := future.Load(); n != 0 {
+		t.Errorf("expected future job not to start, ran %d times", n)
+	}
+
+	c.Start()
+	defer c.Stop()
+	if _, err := c.AddFuncE(Every(time.Minute), func() {}); err != nil {
+		t.Errorf("expected adds to be accepted after restart, got %v", err)
+	}
+}
+
+// TestDrainTimeout verifies that Drain gives up when ctx ends before jobs finish
+func TestDrainTimeout(t *testing.T) {
+	c := New(WithLogger(&recordingLogger{}))
+	release := make(chan struct{})
+	defer close(release)
+	id := c.AddFunc(Every(time.Hour), func() { <-release })
+	c.Start()
+	c.RunNow(id)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := c.Drain(ctx)
+	if !errors.Is(err, ErrShutdownTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected ErrShutdownTimeout wrapping the context error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleSpec 是可序列化的调度描述，Cron和Every必须且只能设置其一
+// JSON形式为{"cron":"*/5 * * * *"}或{"every":"5m"}，间隔使用time.ParseDuration的格式
+type ScheduleSpec struct {
+	Cron  string        // 5字段cron表达式，或以秒为第一个字段的6字段表达式
+	Every time.Duration // 固定的执行间隔，对应Every
+}
+
+// scheduleSpecJSON 是ScheduleSpec的JSON表示
+type scheduleSpecJSON struct {
+	Cron  string `json:"cron,omitempty"`
+	Every string `json:"every,omitempty"`
+}
+
+// Schedule 根据描述构造调度器
+// 6字段的表达式按ParseWithSeconds解析，其余按Parse解析；描述无效时返回错误
+func (s ScheduleSpec) Schedule() (Schedule, error) {
+	switch {
+	case s.Cron != "" && s.Every != 0:
+		return nil, errors.New("schedule spec cannot set both cron and every")
+	case s.Cron != "":
+		if len(strings.Fields(s.Cron)) == 6 {
+			return ParseWithSeconds(s.Cron)
+		}
+		return Parse(s.Cron)
+	case s.Every < 0:
+		return nil, fmt.Errorf("schedule spec interval %v must be positive", s.Every)
+	case s.Every > 0:
+		return Every(s.Every), nil
+	default:
+		return nil, errors.New("schedule spec must set cron or every")
+	}
+}
+
+// MarshalJSON 实现json.Marshaler接口，描述无效时返回错误
+func (s ScheduleSpec) MarshalJSON() ([]byte, error) {
+	if _, err := s.Schedule(); err != nil {
+		return nil, err
+	}
+	v := scheduleSpecJSON{Cron: s.Cron}
+	if s.Every != 0 {
+		v.Every = s.Every.String()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON 实现json.Unmarshaler接口
+// 解码时即检查描述是否有效，无效的配置在加载阶段就会报错
+func (s *ScheduleSpec) UnmarshalJSON(data []byte) error {
+	var v scheduleSpecJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	spec := ScheduleSpec{Cron: v.Cron}
+	if v.Every != "" {
+		d, err := time.ParseDuration(v.Every)
+		if err != nil {
+			return fmt.Errorf("invalid schedule spec interval %q: %w", v.Every, err)
+		}
+		spec.Every = d
+	}
+	if _, err := spec.Schedule(); err != nil {
+		return err
+	}
+	*s = spec
+	return nil
+}
+
+// EntryConfig 是可序列化的任务定义，用于持久化任务配置并在重启后重建任务
+// 任务函数无法序列化，Job只保存任务的名称，由调用者映射回具体的Job
+type EntryConfig struct {
+	Name     string       `json:"name,omitempty"` // 任务名称，对应Entry.Name
+	Job      string       `json:"job"`            // 任务的名称，由调用者映射为Job
+	Schedule ScheduleSpec `json:"schedule"`       // 调度描述
+}
+
+// NamedSchedule 使用job和描述构造的调度器生成可传给ApplyConfig的配置项
+// 调度描述无效时返回错误
+func (cfg EntryConfig) NamedSchedule(job Job) (NamedSchedule, error) {
+	s, err := cfg.Schedule.Schedule()
+	if err != nil {
+		return NamedSchedule{}, fmt.Errorf("entry %q: %w", cfg.Name, err)
+	}
+	return NamedSchedule{Name: cfg.Name, Schedule: s, Job: job}, nil
+}
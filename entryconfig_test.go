@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestEntryConfigRoundTrip verifies that interval and cron-string schedules survive JSON encoding
+func TestEntryConfigRoundTrip(t *testing.T) {
+	configs := []EntryConfig{
+		{Name: "report", Job: "send-report", Schedule: ScheduleSpec{Cron: "0 9 * * 1"}},
+		{Name: "poll", Job: "poll-queue", Schedule: ScheduleSpec{Every: 90 * time.Second}},
+		{Name: "tick", Job: "tick", Schedule: ScheduleSpec{Cron: "*/30 * * * * *"}},
+	}
+	data, err := json.Marshal(configs)
+	if err != nil {
+		t.Fatalf("unexpected marshal error: %v", err)
+	}
+	expected := `[{"name":"report","job":"send-report","schedule":{"cron":"0 9 * * 1"}},` +
+		`{"name":"poll","job":"poll-queue","schedule":{"every":"1m30s"}},` +
+		`{"name":"tick","job":"tick","schedule":{"cron":"*/30 * * * * *"}}]`
+	if string(data) != expected {
+		t.Errorf("expected %s, got %s", expected, data)
+	}
+
+	var decoded []EntryConfig
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, configs) {
+		t.Errorf("expected %v, got %v", configs, decoded)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, want := range []time.Time{
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		now.Add(90 * time.Second),
+		now.Add(30 * time.Second),
+	} {
+		ns, err := decoded[i].NamedSchedule(FuncJob(func() {}))
+		if err != nil {
+			t.Fatalf("unexpected error rebuilding %q: %v", decoded[i].Name, err)
+		}
+		if ns.Name != decoded[i].Name {
+			t.Errorf("expected name %q, got %q", decoded[i].Name, ns.Name)
+		}
+		if got := ns.Schedule.Next(now); !got.Equal(want) {
+			t.Errorf("%s: expected next %v, got %v", decoded[i].Name, want, got)
+		}
+	}
+}
+
+// TestScheduleSpecInvalid verifies that invalid specs are rejected when encoding and decoding
+func TestScheduleSpecInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{}`,
+		`{"cron":"* * *"}`,
+		`{"every":"soon"}`,
+		`{"every":"-1m"}`,
+		`{"cron":"* * * * *","every":"1m"}`,
+	} {
+		var s ScheduleSpec
+		if err := json.Unmarshal([]byte(data), &s); err == nil {
+			t.Errorf("expected error decoding %s", data)
+		}
+	}
+	if _, err := json.Marshal(ScheduleSpec{}); err == nil {
+		t.Error("expected error encoding an empty spec")
+	}
+	if _, err := (EntryConfig{Name: "bad"}).NamedSchedule(FuncJob(func() {})); err == nil {
+		t.Error("expected error rebuilding an entry without a schedule")
+	}
+}
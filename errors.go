@@ -0,0 +1,33 @@
+package cron
+
+import "errors"
+
+// ErrEntryNotFound 表示指定ID的任务不存在
+var ErrEntryNotFound = errors.New("entry not found")
+
+// ErrInvalidSchedule 表示任务的调度器无法给出有效的下次执行时间
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// ErrIntervalTooShort 表示调度器的执行间隔短于WithMinInterval设置的最小间隔
+var ErrIntervalTooShort = errors.New("schedule interval too short")
+
+// ErrSchedulerRunning 表示调度器已经在运行
+var ErrSchedulerRunning = errors.New("scheduler already running")
+
+// ErrShutdownTimeout 表示停止调度器时正在执行的任务未能在限定时间内完成
+var ErrShutdownTimeout = errors.New("shutdown timed out waiting for jobs")
+
+// ErrDuplicateName 表示已存在同名的任务
+var ErrDuplicateName = errors.New("duplicate entry name")
+
+// ErrNilSchedule 表示添加任务时调度器为nil
+var ErrNilSchedule = errors.New("schedule cannot be nil")
+
+// ErrNilJob 表示添加任务时任务为nil
+var ErrNilJob = errors.New("job cannot be nil")
+
+// ErrDraining 表示调度器正在通过Drain停止，不再接受新的任务
+var ErrDraining = errors.New("scheduler is draining")
+
+// ErrUnknownJob 表示任务名称没有在JobRegistry中注册
+var ErrUnknownJob = errors.New("unknown job")
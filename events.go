@@ -0,0 +1,132 @@
+package cron
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// EventKind 表示调度器事件的类型
+type EventKind string
+
+const (
+	EventScheduled EventKind = "scheduled" // 计算了任务的下次执行时间
+	EventFired     EventKind = "fired"     // 任务被触发
+	EventSkipped   EventKind = "skipped"   // 任务到期但被跳过
+	EventAdded     EventKind = "added"     // 任务被添加
+	EventRemoved   EventKind = "removed"   // 任务被删除
+	EventStarted   EventKind = "started"   // 任务开始执行
+	EventFinished  EventKind = "finished"  // 任务执行结束(包括返回错误)
+	EventPanicked  EventKind = "panicked"  // 任务执行时发生panic
+)
+
+// eventStreamBuffer 是Events返回的通道的缓冲区大小
+const eventStreamBuffer = 64
+
+// Event 是调度器做出的一次决策记录
+type Event struct {
+	Kind    EventKind // 事件类型
+	EntryID EntryID   // 相关任务ID
+	Time    time.Time // 事件发生时间
+	Next    time.Time // 事件发生后任务的下次执行时间
+}
+
+// eventRing 是固定容量的事件环形缓冲区，写满后覆盖最旧的事件
+type eventRing struct {
+	mu    sync.Mutex
+	buf   []Event
+	start int // 最旧事件的位置
+	n     int // 当前事件数量
+}
+
+// newEventRing 创建容量为size的环形缓冲区
+func newEventRing(size int) *eventRing {
+	return &eventRing{buf: make([]Event, size)}
+}
+
+// push 追加一个事件
+func (r *eventRing) push(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.n < len(r.buf) {
+		r.buf[(r.start+r.n)%len(r.buf)] = ev
+		r.n++
+		return
+	}
+	r.buf[r.start] = ev
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+// last 按时间顺序返回最近的n个事件
+func (r *eventRing) last(n int) []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n <= 0 || n > r.n {
+		n = r.n
+	}
+	events := make([]Event, n)
+	for i := range events {
+		events[i] = r.buf[(r.start+r.n-n+i)%len(r.buf)]
+	}
+	return events
+}
+
+// record 在启用了事件缓冲区时记录一个调度决策事件，并发送给所有订阅者
+func (c *Cron) record(kind EventKind, id EntryID, now, next time.Time) {
+	ev := Event{Kind: kind, EntryID: id, Time: now, Next: next}
+	if c.events != nil {
+		c.events.push(ev)
+	}
+	c.publish(ev)
+}
+
+// publish 将事件发送给所有通过Events订阅的通道
+// 任务执行的开始、结束和panic不属于调度决策，只发送给订阅者而不进入事件缓冲区
+func (c *Cron) publish(ev Event) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	for _, ch := range c.subs {
+		select {
+		case ch <- ev:
+		default:
+			// 消费者跟不上时丢弃事件，不阻塞主循环和任务
+		}
+	}
+}
+
+// Events 订阅调度器的事件流，返回一个新的通道，之后发生的每个事件都会发送到该通道
+// 事件包括RecentEvents记录的所有调度决策，以及每次执行的开始、结束和panic
+// 通道带有缓冲区，缓冲区满时新事件会被丢弃，慢消费者不会阻塞调度器
+// 不再需要订阅时应通过Unsubscribe移除并关闭通道，否则通道会一直保留在调度器中
+// 不需要启用WithEventBuffer，可以多次调用以创建多个互不影响的订阅
+func (c *Cron) Events() <-chan Event {
+	ch := make(chan Event, eventStreamBuffer)
+	c.subsMu.Lock()
+	c.subs = append(c.subs, ch)
+	c.subsMu.Unlock()
+	return ch
+}
+
+// Unsubscribe 取消Events返回的订阅并关闭通道，通道中已缓冲的事件仍可读出
+// ch不是当前的订阅(包括已经取消的订阅)时不做任何操作
+func (c *Cron) Unsubscribe(ch <-chan Event) {
+	// 在subsMu内移除并关闭，publish不会向已关闭的通道发送
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+	i := slices.IndexFunc(c.subs, func(s chan Event) bool { return s == ch })
+	if i < 0 {
+		return
+	}
+	close(c.subs[i])
+	c.subs = slices.Delete(c.subs, i, i+1)
+}
+
+// RecentEvents 按时间顺序返回最近的n个调度决策事件，n小于等于0时返回缓冲区中的全部事件
+// 事件与Info日志记录的信息相同，便于事后排查而无需检索日志
+// 需要通过WithEventBuffer启用，未启用时返回nil
+func (c *Cron) RecentEvents(n int) []Event {
+	if c.events == nil {
+		return nil
+	}
+	return c.events.last(n)
+}
@@ -0,0 +1,178 @@
+package cron
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestRecentEventsRing verifies that the buffer keeps only the most recent events in order
+func TestRecentEventsRing(t *testing.T) {
+	c := New(WithEventBuffer(3))
+	a := c.AddFunc(&TestSchedule{}, func() {})
+	b := c.AddFunc(&TestSchedule{}, func() {})
+	d := c.AddFunc(&TestSchedule{}, func() {})
+	c.Remove(a)
+
+	var got []Event
+	for _, ev := range c.RecentEvents(0) {
+		got = append(got, Event{Kind: ev.Kind, EntryID: ev.EntryID})
+	}
+	expected := []Event{
+		{Kind: EventAdded, EntryID: b},
+		{Kind: EventAdded, EntryID: d},
+		{Kind: EventRemoved, EntryID: a},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if last := c.RecentEvents(1); len(last) != 1 || last[0].Kind != EventRemoved {
+		t.Errorf("expected only the removal event, got %v", last)
+	}
+}
+
+// TestRecentEventsRun verifies that fired jobs and computed next times are recorded in order
+func TestRecentEventsRun(t *testing.T) {
+	c := New(WithEventBuffer(100))
+	id := c.AddFunc(Every(20*time.Millisecond), func() {})
+	c.Start()
+	time.Sleep(70 * time.Millisecond)
+	<-c.Stop().Done()
+
+	events := c.RecentEvents(0)
+	var kinds []EventKind
+	for i, ev := range events {
+		if ev.EntryID != id {
+			t.Errorf("unexpected entry %d in event %v", ev.EntryID, ev)
+		}
+		if i > 0 && ev.Time.Before(events[i-1].Time) {
+			t.Errorf("events out of order: %v before %v", events[i-1], ev)
+		}
+		kinds = append(kinds, ev.Kind)
+	}
+	if len(kinds) < 5 {
+		t.Fatalf("expected at least 5 events, got %v", kinds)
+	}
+	expected := []EventKind{EventAdded, EventScheduled, EventFired, EventScheduled, EventFired}
+	if !reflect.DeepEqual(kinds[:5], expected) {
+		t.Errorf("expected events to start with %v, got %v", expected, kinds)
+	}
+	if next := events[1].Next; next.IsZero() {
+		t.Error("scheduled event should carry the next time")
+	}
+
+	if New().RecentEvents(10) != nil {
+		t.Error("expected nil events when the buffer is disabled")
+	}
+}
+
+// collectEvents reads events from ch until one of kind last arrives
+func collectEvents(t *testing.T, ch <-chan Event, last EventKind) []Event {
+	t.Helper()
+	var events []Event
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case ev := <-ch:
+			events = append(events, ev)
+			if ev.Kind == last {
+				return events
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %s event, got %v", last, events)
+		}
+	}
+}
+
+// TestEventsStream verifies the event sequence of an add-run-remove cycle
+func TestEventsStream(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	events := c.Events()
+	defer c.Unsubscribe(events)
+	id := c.AddFunc(Every(time.Minute), func() {})
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	c.Remove(id)
+
+	// the job runs concurrently with the loop, so check loop and job events separately
+	var loop, job []EventKind
+	for _, ev := range collectEvents(t, events, EventRemoved) {
+		if ev.EntryID != id {
+			t.Errorf("unexpected entry %d in event %v", ev.EntryID, ev)
+		}
+		switch ev.Kind {
+		case EventStarted, EventFinished, EventPanicked:
+			job = append(job, ev.Kind)
+		default:
+			loop = append(loop, ev.Kind)
+		}
+	}
+	expectedLoop := []EventKind{EventAdded, EventScheduled, EventFired, EventScheduled, EventRemoved}
+	if !reflect.DeepEqual(loop, expectedLoop) {
+		t.Errorf("expected loop events %v, got %v", expectedLoop, loop)
+	}
+	expectedJob := []EventKind{EventStarted, EventFinished}
+	if !reflect.DeepEqual(job, expectedJob) {
+		t.Errorf("expected job events %v, got %v", expectedJob, job)
+	}
+}
+
+// TestEventsPanicked verifies that a panicking job reports a panicked event instead of finished
+func TestEventsPanicked(t *testing.T) {
+	c := New(WithLogger(&recordingLogger{}))
+	events := c.Events()
+	defer c.Unsubscribe(events)
+	id := c.AddFunc(Every(time.Hour), func() { panic("boom") })
+	c.RunNow(id)
+
+	got := collectEvents(t, events, EventPanicked)
+	for _, ev := range got {
+		if ev.Kind == EventFinished {
+			t.Errorf("unexpected finished event for panicking job: %v", got)
+		}
+	}
+}
+
+// TestEventsSlowConsumer verifies that an unread subscription never blocks the scheduler
+func TestEventsSlowConsumer(t *testing.T) {
+	c := New()
+	events := c.Events()
+	defer c.Unsubscribe(events)
+	for range eventStreamBuffer * 2 {
+		c.Remove(c.AddFunc(Every(time.Minute), func() {}))
+	}
+	if n := len(events); n != eventStreamBuffer {
+		t.Errorf("expected %d buffered events, got %d", eventStreamBuffer, n)
+	}
+}
+
+// TestEventsUnsubscribe verifies that unsubscribing closes the channel and stops delivery
+func TestEventsUnsubscribe(t *testing.T) {
+	c := New()
+	events := c.Events()
+	other := c.Events()
+	defer c.Unsubscribe(other)
+
+	c.Unsubscribe(events)
+	c.Unsubscribe(events)
+	c.AddFunc(Every(time.Minute), func() {})
+	if _, ok := <-events; ok {
+		t.Error("expected unsubscribed channel to be closed without events")
+	}
+	if n := len(other); n == 0 {
+		t.Error("expected the remaining subscription to keep receiving events")
+	}
+	c.subsMu.Lock()
+	n := len(c.subs)
+	c.subsMu.Unlock()
+	if n != 1 {
+		t.Errorf("expected 1 remaining subscriber, got %d", n)
+	}
+}
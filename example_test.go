@@ -6,10 +6,21 @@ import (
 	"time"
 )
 
+// exampleStart 是示例中模拟时钟的起始时间(周一)
+var exampleStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// tick 等待主循环进入等待状态后将模拟时间推进d，再等待主循环处理完到期的任务
+func tick(clk *FakeClock, d time.Duration) {
+	clk.BlockUntil(1)
+	clk.Advance(d)
+	clk.BlockUntil(1)
+}
+
 // Example_basic 展示基础定时任务功能
 func Example_basic() {
-	// 创建调度器实例
-	c := New()
+	// 创建使用模拟时钟的调度器实例，示例无需真实等待
+	clk := NewFakeClock(exampleStart)
+	c := New(WithClock(clk))
 	defer c.Stop()
 
 	// 计数器用于跟踪任务执行次数
@@ -24,8 +35,11 @@ func Example_basic() {
 	// 启动调度器
 	c.Start()
 
-	// 运行300ms后停止，预期执行3次
-	time.Sleep(300 * time.Millisecond)
+	// 推进300ms，预期执行3次
+	for range 3 {
+		tick(clk, 100*time.Millisecond)
+		c.WaitJobs()
+	}
 
 	// Output:
 	// 任务执行次数: 1
@@ -35,8 +49,8 @@ func Example_basic() {
 
 // Example_concurrentJobs 展示并发任务执行
 func Example_concurrentJobs() {
-	c := New()
-	defer c.Stop()
+	clk := NewFakeClock(exampleStart)
+	c := New(WithClock(clk))
 
 	// 任务1: 短任务
 	c.AddFunc(Every(100*time.Millisecond), func() {
@@ -46,29 +60,34 @@ func Example_concurrentJobs() {
 	// 任务2: 长任务（模拟耗时操作）
 	c.AddFunc(Every(200*time.Millisecond), func() {
 		fmt.Println("长任务开始")
-		time.Sleep(150 * time.Millisecond) // 模拟耗时
+		time.Sleep(10 * time.Millisecond) // 模拟耗时
 		fmt.Println("长任务结束")
 	})
 
 	c.Start()
-	// 运行500ms观察并发行为
-	time.Sleep(500 * time.Millisecond)
+	// 推进400ms，不等待任务结束，长任务与短任务并发执行，输出顺序不固定
+	for range 4 {
+		tick(clk, 100*time.Millisecond)
+	}
+	// 停止调度器并等待正在执行的任务结束
+	<-c.Stop().Done()
 
-	// Output:
+	// Unordered output:
 	// 短任务执行
-	// 长任务开始
 	// 短任务执行
-	// 长任务结束
 	// 短任务执行
-	// 长任务开始
 	// 短任务执行
+	// 长任务开始
+	// 长任务结束
+	// 长任务开始
 	// 长任务结束
 }
 
 // Example_customJob 展示自定义Job接口实现
 func Example_customJob() {
 	// 创建调度器和任务实例
-	c := New()
+	clk := NewFakeClock(exampleStart)
+	c := New(WithClock(clk))
 	job := &CounterJob{Name: "自定义计数器任务"}
 	defer c.Stop()
 
@@ -76,8 +95,11 @@ func Example_customJob() {
 	c.AddJob(Every(150*time.Millisecond), job)
 	c.Start()
 
-	// 运行450ms，预期执行3次
-	time.Sleep(450 * time.Millisecond)
+	// 推进450ms，预期执行3次
+	for range 3 {
+		tick(clk, 150*time.Millisecond)
+		c.WaitJobs()
+	}
 
 	// Output:
 	// 自定义计数器任务: 执行次数=1
@@ -87,24 +109,46 @@ func Example_customJob() {
 
 // Example_customSchedule 展示自定义调度策略实现
 func Example_customSchedule() {
-	// 创建调度器和任务
-	c := New()
+	// 创建调度器和任务，模拟时钟让每周执行的任务无需真实等待
+	clk := NewFakeClock(exampleStart)
+	c := New(WithClock(clk), WithLocation(time.UTC))
 	defer c.Stop()
 
-	// 使用自定义调度器（为测试方便，这里使用每100ms模拟每周执行）
-	// 实际使用时应设置为 &WeeklySchedule{Hour: 9, Minute: 0, Weekday: 1}（周一9点）
-	simulatedWeekly := &WeeklySchedule{}
-	c.AddFunc(simulatedWeekly, func() {
-		fmt.Println("每周任务执行")
+	// 每周一上午9点执行
+	weekly := &WeeklySchedule{Hour: 9, Minute: 0, Weekday: time.Monday}
+	c.AddFunc(weekly, func() {
+		fmt.Println("每周任务执行:", clk.Now().Format("2006-01-02 15:04 Mon"))
 	})
 
 	c.Start()
-	// 短时间运行以验证调度逻辑
-	time.Sleep(250 * time.Millisecond)
+	// 推进到周一9点，再推进一周以验证调度逻辑
+	tick(clk, 9*time.Hour)
+	c.WaitJobs()
+	tick(clk, 7*24*time.Hour)
+	c.WaitJobs()
+
+	// Output:
+	// 每周任务执行: 2024-01-01 09:00 Mon
+	// 每周任务执行: 2024-01-08 09:00 Mon
+}
+
+// ExampleFakeClock 展示用FakeClock触发每小时执行的任务，无需真实等待一小时
+func ExampleFakeClock() {
+	clk := NewFakeClock(exampleStart)
+	c := New(WithClock(clk))
+	defer c.Stop()
+
+	c.AddFunc(Every(time.Hour), func() {
+		fmt.Println("每小时任务执行")
+	})
+	c.Start()
+
+	// 主循环等待定时器后推进一小时，任务立即被触发
+	tick(clk, time.Hour)
+	c.WaitJobs()
 
 	// Output:
-	// 每周任务执行
-	// 每周任务执行
+	// 每小时任务执行
 }
 
 // 自定义任务类型
@@ -133,13 +177,13 @@ func (s *WeeklySchedule) Next(t time.Time) time.Time {
 
 	// 调整到目标星期几
 	daysAhead := int(s.Weekday - target.Weekday())
-	if daysAhead <= 0 {
+	if daysAhead < 0 {
 		daysAhead += 7
 	}
 
-	// 如果目标时间已过，加一周
+	// 如果目标时间已过(包括恰好是本次执行时间)，加一周
 	next := target.AddDate(0, 0, daysAhead)
-	if next.Before(t) {
+	if !next.After(t) {
 		next = next.AddDate(0, 0, 7)
 	}
 
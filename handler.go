@@ -0,0 +1,88 @@
+package cron
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// entryView 是任务在HTTP接口中的JSON表示
+type entryView struct {
+	ID     EntryID   `json:"id"`
+	Next   time.Time `json:"next"`
+	Prev   time.Time `json:"prev"`
+	Paused bool      `json:"paused"`
+}
+
+// Handler 返回一个管理任务的http.Handler
+// 支持的请求:
+//
+//	GET                          - 以JSON数组返回Entries()
+//	POST ?action=trigger&id=ID   - 立即执行任务
+//	POST ?action=pause&id=ID     - 暂停任务
+//	POST ?action=resume&id=ID    - 恢复任务
+//	POST ?action=remove&id=ID    - 删除任务
+//
+// POST成功时返回204，任务不存在时返回404
+// 可与调度器并发使用
+func (c *Cron) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			c.serveEntries(w)
+		case http.MethodPost:
+			c.serveAction(w, r)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// serveEntries 以JSON格式输出所有任务
+func (c *Cron) serveEntries(w http.ResponseWriter) {
+	entries := c.Entries()
+	views := make([]entryView, 0, len(entries))
+	for _, e := range entries {
+		views = append(views, entryView{ID: e.ID, Next: e.Next, Prev: e.Prev, Paused: e.Paused})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(views); err != nil {
+		c.logger.Error("encode entries", "error", err)
+	}
+}
+
+// serveAction 处理针对单个任务的操作
+func (c *Cron) serveAction(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	id := EntryID(n)
+
+	var found bool
+	switch r.URL.Query().Get("action") {
+	case "trigger":
+		found = c.RunNow(id) == nil
+	case "pause":
+		found = c.PauseEntry(id)
+	case "resume":
+		found = c.ResumeEntry(id)
+	case "remove":
+		_, found = c.Entry(id)
+		if found {
+			c.Remove(id)
+		}
+	default:
+		http.Error(w, "unknown action", http.StatusBadRequest)
+		return
+	}
+
+	if !found {
+		http.Error(w, ErrEntryNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
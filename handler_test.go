@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestHandlerListEntries verifies that GET returns all entries as JSON
+func TestHandlerListEntries(t *testing.T) {
+	c := New()
+	id1 := c.AddFunc(&TestSchedule{}, func() {})
+	id2 := c.AddFunc(&TestSchedule{}, func() {})
+	c.Start()
+	defer c.Stop()
+
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var views []entryView
+	if err := json.Unmarshal(rec.Body.Bytes(), &views); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if len(views) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(views))
+	}
+	ids := map[EntryID]bool{}
+	for _, v := range views {
+		ids[v.ID] = true
+	}
+	if !ids[id1] || !ids[id2] {
+		t.Errorf("expected entries %d and %d, got %v", id1, id2, views)
+	}
+}
+
+// TestHandlerTrigger verifies that POST with action=trigger runs the job
+func TestHandlerTrigger(t *testing.T) {
+	c := New()
+	ran := make(chan struct{}, 1)
+	id := c.AddFunc(&TestSchedule{}, func() { ran <- struct{}{} })
+	c.Start()
+	defer c.Stop()
+
+	rec := httptest.NewRecorder()
+	target := fmt.Sprintf("/?action=trigger&id=%d", id)
+	c.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Error("job was not triggered")
+	}
+}
+
+// TestHandlerActions verifies pause, resume and remove requests and error responses
+func TestHandlerActions(t *testing.T) {
+	c := New()
+	id := c.AddFunc(&TestSchedule{}, func() {})
+	c.Start()
+	defer c.Stop()
+	h := c.Handler()
+
+	post := func(query string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/?"+query, nil))
+		return rec.Code
+	}
+
+	if code := post(fmt.Sprintf("action=pause&id=%d", id)); code != http.StatusNoContent {
+		t.Errorf("pause: expected status 204, got %d", code)
+	}
+	if e, _ := c.Entry(id); !e.Paused {
+		t.Error("entry should be paused")
+	}
+	if code := post(fmt.Sprintf("action=resume&id=%d", id)); code != http.StatusNoContent {
+		t.Errorf("resume: expected status 204, got %d", code)
+	}
+	if e, _ := c.Entry(id); e.Paused {
+		t.Error("entry should not be paused")
+	}
+	if code := post(fmt.Sprintf("action=remove&id=%d", id)); code != http.StatusNoContent {
+		t.Errorf("remove: expected status 204, got %d", code)
+	}
+	// the run loop applies removals before it handles the stop signal
+	c.Stop()
+	if len(c.Entries()) != 0 {
+		t.Error("entry should be removed")
+	}
+	if code := post(fmt.Sprintf("action=trigger&id=%d", id)); code != http.StatusNotFound {
+		t.Errorf("unknown id: expected status 404, got %d", code)
+	}
+	if code := post("action=trigger&id=abc"); code != http.StatusBadRequest {
+		t.Errorf("invalid id: expected status 400, got %d", code)
+	}
+	if code := post("action=explode&id=1"); code != http.StatusBadRequest {
+		t.Errorf("unknown action: expected status 400, got %d", code)
+	}
+}
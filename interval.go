@@ -0,0 +1,71 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// intervalSamples 是估算调度器最小间隔时采样的执行次数
+const intervalSamples = 16
+
+// defaultIntervalFloor 是默认的安全间隔下限
+// 主循环每次醒来都要处理到期任务、计算下次执行时间并重新创建定时器，
+// 再加上操作系统定时器的唤醒延迟，更短的间隔难以保证准时触发
+const defaultIntervalFloor = 10 * time.Millisecond
+
+// minGap 通过连续调用Next采样，估算调度器相邻两次执行之间的最小间隔
+// 采样不足两次执行(如一次性调度器)时返回0和false
+func minGap(s Schedule, now time.Time) (time.Duration, bool) {
+	prev := s.Next(now)
+	if prev.IsZero() {
+		return 0, false
+	}
+	var gap time.Duration
+	found := false
+	for i := 1; i < intervalSamples; i++ {
+		next := s.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if d := next.Sub(prev); !found || d < gap {
+			gap = d
+			found = true
+		}
+		prev = next
+	}
+	return gap, found
+}
+
+// checkInterval 在设置了WithMinInterval时检查调度器的执行间隔是否过短
+// 固定间隔的调度器短于安全下限时只记录日志，不会拒绝
+func (c *Cron) checkInterval(s Schedule) error {
+	c.warnShortInterval(s)
+	if c.minInterval <= 0 || s == nil {
+		return nil
+	}
+	gap, ok := minGap(s, c.now())
+	if ok && gap < c.minInterval {
+		return fmt.Errorf("%w: %v is shorter than %v", ErrIntervalTooShort, gap, c.minInterval)
+	}
+	return nil
+}
+
+// warnShortInterval 在固定间隔调度器的间隔短于WithIntervalFloor设置的下限时记录Error日志
+// 只检查DelaySchedule和FixedDelaySchedule，避免为估算间隔调用其他调度器的Next产生副作用
+func (c *Cron) warnShortInterval(s Schedule) {
+	if c.intervalFloor <= 0 {
+		return
+	}
+	var delay time.Duration
+	switch s := s.(type) {
+	case DelaySchedule:
+		delay = s.Delay
+	case FixedDelaySchedule:
+		delay = s.Delay
+	default:
+		return
+	}
+	if delay < c.intervalFloor {
+		c.logger.Error("interval below safe floor", "interval", delay, "floor", c.intervalFloor)
+	}
+}
@@ -0,0 +1,107 @@
+package cron
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestWithMinIntervalCron verifies that cron specs firing more often than the minimum are rejected
+func TestWithMinIntervalCron(t *testing.T) {
+	c := New(WithMinInterval(5 * time.Minute))
+
+	if _, err := c.AddCron("* * * * *", func() {}); !errors.Is(err, ErrIntervalTooShort) {
+		t.Errorf("expected ErrIntervalTooShort for every-minute spec, got %v", err)
+	}
+	if _, err := c.AddCron("0,1 * * * *", func() {}); !errors.Is(err, ErrIntervalTooShort) {
+		t.Errorf("expected ErrIntervalTooShort for uneven spec, got %v", err)
+	}
+	id, err := c.AddCron("*/5 * * * *", func() {})
+	if err != nil {
+		t.Fatalf("unexpected error for every-5-minutes spec: %v", err)
+	}
+	if len(c.Entries()) != 1 || c.Entries()[0].ID != id {
+		t.Errorf("expected only the accepted entry to be registered, got %v", c.Entries())
+	}
+}
+
+// TestWithMinIntervalEvery verifies that interval schedules are checked when added through AddFunc
+func TestWithMinIntervalEvery(t *testing.T) {
+	c := New(WithMinInterval(time.Minute))
+
+	if id := c.AddFunc(Every(time.Second), func() {}); id != 0 {
+		t.Errorf("expected rejected entry to return ID 0, got %d", id)
+	}
+	if id := c.AddFunc(Every(time.Hour), func() {}); id == 0 {
+		t.Error("expected hourly entry to be accepted")
+	}
+	if id := c.AddFunc(EveryFromMidnight(2*time.Hour), func() {}); id == 0 {
+		t.Error("expected two-hourly entry to be accepted")
+	}
+	if got := len(c.Entries()); got != 2 {
+		t.Errorf("expected 2 entries, got %d", got)
+	}
+}
+
+// TestIntervalFloorWarning verifies that intervals below the safe floor are logged but still added
+func TestIntervalFloorWarning(t *testing.T) {
+	logger := &recordingLogger{}
+	c := New(WithLogger(logger))
+
+	if id := c.AddFunc(Every(time.Millisecond), func() {}); id == 0 {
+		t.Error("expected short interval to be accepted")
+	}
+	if !slices.Contains(logger.messages, "error interval below safe floor") {
+		t.Errorf("expected a warning for 1ms interval, got %v", logger.messages)
+	}
+
+	logger.messages = nil
+	c.AddFunc(Every(10*time.Millisecond), func() {})
+	c.AddFunc(FixedDelay(time.Second), func() {})
+	if slices.Contains(logger.messages, "error interval below safe floor") {
+		t.Errorf("unexpected warning for intervals at or above the floor: %v", logger.messages)
+	}
+
+	quiet := &recordingLogger{}
+	c = New(WithLogger(quiet), WithIntervalFloor(0))
+	c.AddFunc(Every(time.Millisecond), func() {})
+	if len(quiet.messages) != 0 {
+		t.Errorf("expected no warning with the floor disabled, got %v", quiet.messages)
+	}
+	if err := WithIntervalFloor(-time.Second)(&Cron{}); err == nil {
+		t.Error("expected error for negative interval floor")
+	}
+}
+
+// BenchmarkScheduleJitter measures how far the gaps between real fires deviate from the interval
+func BenchmarkScheduleJitter(b *testing.B) {
+	for _, interval := range []time.Duration{10 * time.Millisecond, time.Millisecond} {
+		b.Run(interval.String(), func(b *testing.B) {
+			c := New(WithIntervalFloor(0))
+			fires := make(chan time.Time, b.N+1)
+			c.AddFunc(Every(interval), func() {
+				select {
+				case fires <- time.Now():
+				default:
+				}
+			})
+			b.ResetTimer()
+			c.Start()
+			var total time.Duration
+			prev := <-fires
+			for i := 0; i < b.N; i++ {
+				next := <-fires
+				gap := next.Sub(prev) - interval
+				if gap < 0 {
+					gap = -gap
+				}
+				total += gap
+				prev = next
+			}
+			b.StopTimer()
+			<-c.Stop().Done()
+			b.ReportMetric(float64(total)/float64(b.N), "jitter-ns/op")
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cron
+
+import "time"
+
+// jitter 在设置了WithAutoJitter时为DelaySchedule的下次执行时间附加[0, fraction*Delay)的随机延迟
+func (c *Cron) jitter(s Schedule, next time.Time) time.Time {
+	delay := jitterDelay(s)
+	if c.autoJitter <= 0 || next.IsZero() || delay <= 0 {
+		return next
+	}
+	return next.Add(time.Duration(c.randFloat() * c.autoJitter * float64(delay)))
+}
+
+// jitterDelay 返回WithAutoJitter计算随机延迟时使用的间隔，不是DelaySchedule时返回0
+func jitterDelay(s Schedule) time.Duration {
+	switch d := s.(type) {
+	case DelaySchedule:
+		return d.Delay
+	case *DelaySchedule:
+		return d.Delay
+	}
+	return 0
+}
+
+// JitterSchedule 为内部调度器计算出的每个下次执行时间附加[0, Max)的随机延迟
+// 多个实例运行相同的任务时，可以避免它们在同一时刻集中触发
+type JitterSchedule struct {
+	Schedule Schedule      // 被包装的调度器
+	Max      time.Duration // 随机延迟的上限(不含)，不为正时不附加延迟
+	Rand     Rand          // 随机数源，为nil时使用全局随机源
+}
+
+// WithJitter 包装s，为其每个下次执行时间附加[0, max)的随机延迟
+// 例如: WithJitter(Every(time.Hour), time.Minute)每小时执行一次，每次最多推迟1分钟
+func WithJitter(s Schedule, max time.Duration) *JitterSchedule {
+	return &JitterSchedule{
+		Schedule: s,
+		Max:      max,
+	}
+}
+
+// Next 计算下一次执行时间
+// 每次调用都会重新生成随机延迟，因此对同一个t的多次调用可能返回不同的时间
+// 延迟总是非负的，返回的时间不会早于内部调度器的结果；内部调度器返回零值时同样返回零值
+func (s *JitterSchedule) Next(t time.Time) time.Time {
+	next := s.Schedule.Next(t)
+	if next.IsZero() || s.Max <= 0 {
+		return next
+	}
+	return next.Add(time.Duration(randOrDefault(s.Rand).Int64N(int64(s.Max))))
+}
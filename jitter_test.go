@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestAutoJitter verifies that delay schedules get a random offset within the configured band
+func TestAutoJitter(t *testing.T) {
+	c := New(WithRand(rand.New(rand.NewPCG(1, 2))), WithAutoJitter(0.2))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	delay := 10 * time.Second
+
+	seen := map[time.Duration]bool{}
+	now := base
+	for range 100 {
+		next := c.scheduleNext(&Entry{ID: 1, Schedule: Every(delay)}, now)
+		gap := next.Sub(now)
+		if gap < delay || gap >= delay+2*time.Second {
+			t.Fatalf("expected interval in [%v, %v), got %v", delay, delay+2*time.Second, gap)
+		}
+		seen[gap] = true
+		now = next
+	}
+	if len(seen) < 50 {
+		t.Errorf("expected intervals to vary, got %d distinct values", len(seen))
+	}
+
+	if next := c.scheduleNext(&Entry{ID: 1, Schedule: &TestSchedule{}}, base); !next.Equal(base.Add(time.Hour)) {
+		t.Errorf("expected non-delay schedule to be unchanged, got %v", next)
+	}
+}
+
+// TestAutoJitterConcurrent verifies that the shared random source is safe to use from several goroutines,
+// as happens when jobs, Reschedule and the run loop compute next runs at the same time
+func TestAutoJitterConcurrent(t *testing.T) {
+	c := New(WithRand(rand.New(rand.NewPCG(1, 2))), WithAutoJitter(0.2))
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e := &Entry{ID: EntryID(i + 1), Schedule: Every(time.Minute)}
+			for range 100 {
+				if next := c.scheduleNext(e, base); next.Before(base.Add(time.Minute)) {
+					t.Errorf("expected jittered next run after the interval, got %v", next)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestAutoJitterInvalid verifies that fractions outside [0, 1) are rejected
+func TestAutoJitterInvalid(t *testing.T) {
+	for _, f := range []float64{-0.1, 1} {
+		if err := WithAutoJitter(f)(&Cron{}); err == nil {
+			t.Errorf("expected error for fraction %v", f)
+		}
+	}
+}
+
+// TestWithJitter verifies that the wrapper delays each Next by a seeded amount in [0, max)
+func TestWithJitter(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := WithJitter(Every(time.Minute), 10*time.Second)
+	s.Rand = rand.New(rand.NewPCG(1, 2))
+
+	seen := map[time.Duration]bool{}
+	for range 100 {
+		offset := s.Next(base).Sub(base.Add(time.Minute))
+		if offset < 0 || offset >= 10*time.Second {
+			t.Fatalf("expected offset in [0, 10s), got %v", offset)
+		}
+		seen[offset] = true
+	}
+	if len(seen) < 50 {
+		t.Errorf("expected jitter to be recomputed on every call, got %d distinct offsets", len(seen))
+	}
+
+	again := WithJitter(Every(time.Minute), 10*time.Second)
+	again.Rand = rand.New(rand.NewPCG(1, 2))
+	s.Rand = rand.New(rand.NewPCG(1, 2))
+	if a, b := s.Next(base), again.Next(base); !a.Equal(b) {
+		t.Errorf("expected equal seeds to give equal results, got %v and %v", a, b)
+	}
+}
+
+// TestWithJitterPassthrough verifies that a zero max or a finished schedule is left untouched
+func TestWithJitterPassthrough(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if next := WithJitter(Every(time.Minute), 0).Next(base); !next.Equal(base.Add(time.Minute)) {
+		t.Errorf("expected no jitter with zero max, got %v", next)
+	}
+	if next := WithJitter(RandomWithin(0), time.Minute).Next(base); !next.IsZero() {
+		t.Errorf("expected zero time to pass through, got %v", next)
+	}
+}
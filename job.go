@@ -0,0 +1,136 @@
+package cron
+
+import "context"
+
+// ContextJob 定义了支持取消的定时任务接口
+// Run方法接收的ctx会在Stop被调用时取消，任务应据此尽快返回
+type ContextJob interface {
+	Run(ctx context.Context)
+}
+
+// ContextFuncJob 将接收context的函数转换为ContextJob接口实现
+type ContextFuncJob func(ctx context.Context)
+
+// Run 实现ContextJob接口，调用函数本身
+func (f ContextFuncJob) Run(ctx context.Context) {
+	f(ctx)
+}
+
+// ErrorJob 定义了可能失败的定时任务接口
+// Run返回非nil错误表示本次执行失败，错误会被记录到日志
+type ErrorJob interface {
+	Run() error
+}
+
+// ErrorFuncJob 将返回error的函数转换为ErrorJob接口实现
+type ErrorFuncJob func() error
+
+// Run 实现ErrorJob接口，调用函数本身
+func (f ErrorFuncJob) Run() error {
+	return f()
+}
+
+// contextRunner 由需要调度器传入context或需要返回错误的任务实现
+type contextRunner interface {
+	runContext(ctx context.Context) error
+}
+
+// contextJob 将ContextJob适配为Job，以便与普通任务统一存储和调度
+type contextJob struct {
+	job ContextJob
+}
+
+// Run 实现Job接口，直接调用时使用context.Background()
+func (j contextJob) Run() {
+	j.job.Run(context.Background())
+}
+
+// runContext 使用调度器提供的ctx执行任务
+func (j contextJob) runContext(ctx context.Context) error {
+	j.job.Run(ctx)
+	return nil
+}
+
+// isNil 实现nilChecker接口
+func (j contextJob) isNil() bool {
+	return isNilJob(j.job)
+}
+
+// errorJob 将ErrorJob适配为Job，使调度器能够获取执行结果
+type errorJob struct {
+	job ErrorJob
+}
+
+// Run 实现Job接口，直接调用时忽略错误
+func (j errorJob) Run() {
+	_ = j.job.Run()
+}
+
+// runContext 执行任务并返回其错误
+func (j errorJob) runContext(ctx context.Context) error {
+	return j.job.Run()
+}
+
+// nilChecker 由包装其他任务的适配器实现，报告被包装的任务是否为nil
+type nilChecker interface {
+	isNil() bool
+}
+
+// isNilJob 返回任务是否为nil
+// 包括nil函数转换成的任务，以及AddErrorFunc等辅助方法用适配器包装的nil任务
+func isNilJob(j any) bool {
+	switch f := j.(type) {
+	case nil:
+		return true
+	case FuncJob:
+		return f == nil
+	case ContextFuncJob:
+		return f == nil
+	case ErrorFuncJob:
+		return f == nil
+	case AdaptiveFuncJob:
+		return f == nil
+	case StringFuncJob:
+		return f == nil
+	case nilChecker:
+		return f.isNil()
+	}
+	return false
+}
+
+// isNil 实现nilChecker接口
+func (j errorJob) isNil() bool {
+	return isNilJob(j.job)
+}
+
+// invoke 执行任务，支持context的任务会收到ctx
+// 返回任务的错误，普通Job总是返回nil
+func invoke(ctx context.Context, j Job) error {
+	if r, ok := j.(contextRunner); ok {
+		return r.runContext(ctx)
+	}
+	j.Run()
+	return nil
+}
+
+// AddContextFunc 添加一个接收context的函数作为定时任务
+// 函数收到的ctx会在Stop被调用时取消
+func (c *Cron) AddContextFunc(schedule Schedule, cmd func(ctx context.Context), opts ...EntryOption) EntryID {
+	return c.AddContextJob(schedule, ContextFuncJob(cmd), opts...)
+}
+
+// AddContextJob 添加一个支持取消的任务到调度器
+// 任务收到的ctx会在Stop被调用时取消
+func (c *Cron) AddContextJob(schedule Schedule, cmd ContextJob, opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, contextJob{job: cmd}, opts...)
+}
+
+// AddErrorFunc 添加一个返回error的函数作为定时任务
+func (c *Cron) AddErrorFunc(schedule Schedule, cmd func() error, opts ...EntryOption) EntryID {
+	return c.AddErrorJob(schedule, ErrorFuncJob(cmd), opts...)
+}
+
+// AddErrorJob 添加一个可能失败的任务到调度器
+func (c *Cron) AddErrorJob(schedule Schedule, cmd ErrorJob, opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, errorJob{job: cmd}, opts...)
+}
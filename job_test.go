@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+// TestWithErrorHandler verifies that only failing error jobs reach the handler with their entry ID
+func TestWithErrorHandler(t *testing.T) {
+	type failure struct {
+		id  EntryID
+		err error
+	}
+	failures := make(chan failure, 4)
+	logger := &recordingLogger{}
+	c := New(WithLogger(logger), WithErrorHandler(func(id EntryID, err error) { failures <- failure{id, err} }))
+
+	errBoom := errors.New("boom")
+	failing := c.AddErrorFunc(&TestSchedule{}, func() error { return errBoom })
+	var ok, plain atomic.Int32
+	succeeding := c.AddErrorFunc(&TestSchedule{}, func() error { ok.Add(1); return nil })
+	plainID := c.AddFunc(&TestSchedule{}, func() { plain.Add(1) })
+
+	c.RunNow(failing)
+	c.RunNow(succeeding)
+	c.RunNow(plainID)
+	<-c.Stop().Done()
+
+	if len(failures) != 1 {
+		t.Fatalf("expected exactly one handled error, got %d", len(failures))
+	}
+	if f := <-failures; f.id != failing || !errors.Is(f.err, errBoom) {
+		t.Errorf("expected entry %d with %v, got %+v", failing, errBoom, f)
+	}
+	if ok.Load() != 1 || plain.Load() != 1 {
+		t.Errorf("expected the successful and plain jobs to run once, got %d and %d", ok.Load(), plain.Load())
+	}
+
+	logger.mu.Lock()
+	defer logger.mu.Unlock()
+	var logged bool
+	for _, m := range logger.messages {
+		logged = logged || m == "error job failed"
+	}
+	if !logged {
+		t.Errorf("expected the failure to be logged, got %v", logger.messages)
+	}
+}
+
+// TestWithErrorHandlerPanics verifies that a panicking handler does not crash the job goroutine
+func TestWithErrorHandlerPanics(t *testing.T) {
+	c := New(WithErrorHandler(func(EntryID, error) { panic("handler") }))
+	id := c.AddErrorFunc(&TestSchedule{}, func() error { return errors.New("boom") })
+	c.RunNow(id)
+	<-c.Stop().Done()
+}
+
+// TestWithErrorHandlerNil verifies that a nil handler is rejected
+func TestWithErrorHandlerNil(t *testing.T) {
+	if err := WithErrorHandler(nil)(&Cron{}); err == nil {
+		t.Error("expected error for nil handler")
+	}
+}
@@ -0,0 +1,35 @@
+package cron
+
+import "time"
+
+// LocationAware 由需要知道调度器时区的Schedule实现
+// 调度器传给Next的时间已转换为其配置的时区(见WithLocation)，多数日历类调度器直接使用t.Location()即可；
+// 需要在Next之外使用时区的调度器(例如预先按本地日期计算日历)可以实现此接口
+// 任务加入调度器时，调度器在第一次调用Next之前调用SetLocation传入自己的时区；
+// 同一个Schedule加入多个调度器时，以最后一次加入的调度器的时区为准
+type LocationAware interface {
+	SetLocation(loc *time.Location)
+}
+
+// bindLocation 将调度器的时区传给实现了LocationAware的调度器
+func (c *Cron) bindLocation(s Schedule) {
+	if la, ok := s.(LocationAware); ok {
+		la.SetLocation(c.location)
+	}
+}
+
+// SetLocation 实现LocationAware接口，将时区传给被包装的调度器
+func (s *JitterSchedule) SetLocation(loc *time.Location) {
+	if la, ok := s.Schedule.(LocationAware); ok {
+		la.SetLocation(loc)
+	}
+}
+
+// SetLocation 实现LocationAware接口，将时区传给每个阶段的调度器
+func (s *PhasedSchedule) SetLocation(loc *time.Location) {
+	for _, p := range s.Phases {
+		if la, ok := p.Schedule.(LocationAware); ok {
+			la.SetLocation(loc)
+		}
+	}
+}
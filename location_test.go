@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// zoneRecorder is a LocationAware schedule remembering the injected location and the zones Next sees
+type zoneRecorder struct {
+	mu       sync.Mutex
+	loc      *time.Location
+	early    bool // Next was called before SetLocation
+	nextZone *time.Location
+}
+
+func (s *zoneRecorder) SetLocation(loc *time.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loc = loc
+}
+
+func (s *zoneRecorder) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loc == nil {
+		s.early = true
+	}
+	s.nextZone = t.Location()
+	return t.Add(time.Hour)
+}
+
+// TestLocationAware verifies that schedules receive the scheduler location before Next and see times in it
+func TestLocationAware(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk), WithLocation(tokyo), WithMinInterval(time.Minute))
+	direct := &zoneRecorder{}
+	inner := &zoneRecorder{}
+	c.AddFunc(direct, func() {})
+	c.AddFunc(WithJitter(inner, time.Second), func() {})
+	delay := c.AddFunc(Every(time.Hour), func() {})
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	for name, s := range map[string]*zoneRecorder{"direct": direct, "wrapped": inner} {
+		s.mu.Lock()
+		if s.loc != tokyo {
+			t.Errorf("%s: expected location %v to be injected, got %v", name, tokyo, s.loc)
+		}
+		if s.early {
+			t.Errorf("%s: expected SetLocation before the first Next call", name)
+		}
+		if s.nextZone != tokyo {
+			t.Errorf("%s: expected Next to receive times in %v, got %v", name, tokyo, s.nextZone)
+		}
+		s.mu.Unlock()
+	}
+	if e, _ := c.Entry(delay); e.Next.Location() != tokyo {
+		t.Errorf("expected a delay schedule to keep the scheduler location, got %v", e.Next.Location())
+	}
+}
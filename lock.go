@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Locker 是多实例部署时保证每次触发只在一个实例上执行的分布式锁，通过WithLocker设置
+// Acquire尝试取得名为key的锁，取得时返回true和释放锁的函数，锁已被其他实例持有时返回false；
+// 出错时返回error，该次执行会被跳过。ctx在调度器停止时被取消
+// 键中包含计划时间，实例之间存在时钟偏差时，实现应让锁在释放后仍保留一段时间(如按TTL过期)，
+// 避免较晚触发的实例在先执行的实例释放后再次取得同一次执行的锁
+// Redis、etcd等实现不在本包中提供
+type Locker interface {
+	Acquire(ctx context.Context, key string) (bool, func(), error)
+}
+
+// MemoryLocker 是进程内的Locker实现，用于测试或在同一进程中运行多个调度器
+// 锁释放后键还会保留ttl，期间其他调度器无法再取得同一次执行的锁，
+// 避免执行很快结束时较晚到达的调度器重复执行
+type MemoryLocker struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	held map[string]time.Time // 键的过期时间，零值表示尚未释放
+}
+
+// NewMemoryLocker 创建一个进程内的锁，ttl为释放后保留键的时间，应大于各调度器之间的触发延迟
+func NewMemoryLocker(ttl time.Duration) *MemoryLocker {
+	return &MemoryLocker{ttl: ttl, held: make(map[string]time.Time)}
+}
+
+// Acquire 实现Locker接口
+func (l *MemoryLocker) Acquire(ctx context.Context, key string) (bool, func(), error) {
+	if err := ctx.Err(); err != nil {
+		return false, nil, err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	for k, expires := range l.held {
+		// 清理已经过期的键，避免键随执行次数无限增长
+		if !expires.IsZero() && !now.Before(expires) {
+			delete(l.held, k)
+		}
+	}
+	if _, ok := l.held[key]; ok {
+		return false, nil, nil
+	}
+	l.held[key] = time.Time{}
+	var once sync.Once
+	return true, func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.held[key] = time.Now().Add(l.ttl)
+			l.mu.Unlock()
+		})
+	}, nil
+}
+
+// lockKey 返回任务在计划时间due的执行所使用的锁名称
+// 固定间隔类调度器的计划时间取决于各实例的启动时刻，因此按间隔截断到所在的周期，
+// 使启动时刻不同的实例在同一周期内得到相同的键；cron表达式等按绝对时间触发的调度器直接使用计划时间
+func lockKey(e *Entry, due time.Time) string {
+	name := e.Name
+	if name == "" {
+		name = strconv.Itoa(int(e.ID))
+	}
+	return name + "@" + lockTime(e.Schedule, due).UTC().Format(time.RFC3339Nano)
+}
+
+// lockTime 返回计算锁名称时使用的时间
+// 固定间隔类调度器返回due按间隔截断后的时间，RandomWithin返回due所在周期的开始时刻，其余调度器返回due本身
+func lockTime(s Schedule, due time.Time) time.Time {
+	switch s := s.(type) {
+	case DelaySchedule:
+		return due.Truncate(s.Delay)
+	case *DelaySchedule:
+		return due.Truncate(s.Delay)
+	case FixedDelaySchedule:
+		return due.Truncate(s.Delay)
+	case *RandomWithinSchedule:
+		if s.Period > 0 {
+			return s.windowStart(due)
+		}
+	case *JitterSchedule:
+		return lockTime(s.Schedule, due)
+	}
+	return due
+}
+
+// acquireLock 为任务在计划时间due的执行取得锁，返回释放锁的函数
+// 未取得锁或出错时记录日志并返回false
+func (c *Cron) acquireLock(ctx context.Context, e *Entry, due time.Time) (func(), bool) {
+	key := lockKey(e, due)
+	won, release, err := c.locker.Acquire(ctx, key)
+	if err != nil {
+		c.logger.Error("lock failed", "entry", e.ID, "key", key, "error", err)
+		return nil, false
+	}
+	if !won {
+		now := c.now()
+		c.logEntry("skip", e, "now", now, "entry", e.ID, "reason", "locked")
+		c.record(EventSkipped, e.ID, now, time.Time{})
+		return nil, false
+	}
+	if release == nil {
+		release = func() {}
+	}
+	return release, true
+}
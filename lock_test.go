@@ -0,0 +1,201 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestLockerSingleExecution verifies that two schedulers sharing a locker run each fire once
+func TestLockerSingleExecution(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	locker := NewMemoryLocker(time.Minute)
+	var runs atomic.Int32
+	release := make(chan struct{})
+
+	var replicas []*Cron
+	var skips []<-chan Event
+	for range 2 {
+		c := New(WithClock(clk), WithLocker(locker))
+		events := c.Events()
+		defer c.Unsubscribe(events)
+		skips = append(skips, events)
+		c.AddNamedFunc("report", Every(time.Minute), func() {
+			runs.Add(1)
+			<-release
+		})
+		c.Start()
+		defer c.Stop()
+		replicas = append(replicas, c)
+	}
+
+	clk.BlockUntil(2)
+	clk.Advance(time.Minute)
+
+	// the winner holds the lock until released, so the other replica must skip
+	timeout := time.After(time.Second)
+	for skipped := false; !skipped; {
+		select {
+		case ev := <-skips[0]:
+			skipped = ev.Kind == EventSkipped
+		case ev := <-skips[1]:
+			skipped = ev.Kind == EventSkipped
+		case <-timeout:
+			t.Fatal("timed out waiting for the losing replica to skip")
+		}
+	}
+	close(release)
+	for _, c := range replicas {
+		c.WaitJobs()
+	}
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected a single execution across replicas, got %d", n)
+	}
+}
+
+// TestLockerQuickJob verifies that a job finishing before the other replica fires still runs once
+func TestLockerQuickJob(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	locker := NewMemoryLocker(time.Minute)
+	var runs atomic.Int32
+
+	var replicas []*Cron
+	for range 2 {
+		c := New(WithClock(clk), WithLocker(locker))
+		c.AddNamedFunc("report", Every(time.Minute), func() { runs.Add(1) })
+		c.Start()
+		defer c.Stop()
+		replicas = append(replicas, c)
+	}
+
+	for i := 1; i <= 3; i++ {
+		clk.BlockUntil(2)
+		clk.Advance(time.Minute)
+		clk.BlockUntil(2)
+		for _, c := range replicas {
+			c.WaitJobs()
+		}
+		if n := runs.Load(); n != int32(i) {
+			t.Fatalf("expected %d executions after %d fires, got %d", i, i, n)
+		}
+	}
+}
+
+// TestLockerOffsetReplicas verifies that interval entries on replicas started at different times share each period
+func TestLockerOffsetReplicas(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	locker := NewMemoryLocker(time.Minute)
+	var runs atomic.Int32
+
+	var clocks []*FakeClock
+	var replicas []*Cron
+	for _, offset := range []time.Duration{0, 10 * time.Second} {
+		clk := NewFakeClock(start.Add(offset))
+		c := New(WithClock(clk), WithLocker(locker))
+		c.AddNamedFunc("report", Every(time.Minute), func() { runs.Add(1) })
+		c.Start()
+		defer c.Stop()
+		clocks = append(clocks, clk)
+		replicas = append(replicas, c)
+	}
+
+	for i := 1; i <= 3; i++ {
+		for j, clk := range clocks {
+			clk.BlockUntil(1)
+			clk.Advance(time.Minute)
+			clk.BlockUntil(1)
+			replicas[j].WaitJobs()
+		}
+		if n := runs.Load(); n != int32(i) {
+			t.Fatalf("expected %d executions after %d periods, got %d", i, i, n)
+		}
+	}
+}
+
+// TestLockKey verifies that interval schedules are keyed by period and cron schedules by the exact due time
+func TestLockKey(t *testing.T) {
+	due := time.Date(2024, 1, 1, 0, 1, 10, 0, time.UTC)
+	spec, _ := Parse("* * * * *")
+	for _, tc := range []struct {
+		schedule Schedule
+		want     string
+	}{
+		{Every(time.Minute), "job@2024-01-01T00:01:00Z"},
+		{FixedDelay(time.Minute), "job@2024-01-01T00:01:00Z"},
+		{RandomWithin(time.Hour), "job@2024-01-01T00:00:00Z"},
+		{WithJitter(Every(time.Minute), time.Second), "job@2024-01-01T00:01:00Z"},
+		{spec, "job@2024-01-01T00:01:10Z"},
+	} {
+		if got := lockKey(&Entry{Name: "job", Schedule: tc.schedule}, due); got != tc.want {
+			t.Errorf("%T: expected key %q, got %q", tc.schedule, tc.want, got)
+		}
+	}
+}
+
+// failingLocker always fails to acquire the lock
+type failingLocker struct{}
+
+func (failingLocker) Acquire(context.Context, string) (bool, func(), error) {
+	return false, nil, errors.New("backend unavailable")
+}
+
+// TestLockerError verifies that a lock error skips the scheduled run but not RunNow
+func TestLockerError(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	logger := &recordingLogger{}
+	c := New(WithClock(clk), WithLocker(failingLocker{}), WithLogger(logger))
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if n := runs.Load(); n != 0 {
+		t.Errorf("expected scheduled run to be skipped on lock error, got %d runs", n)
+	}
+
+	c.RunNow(id)
+	c.WaitJobs()
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected RunNow to bypass the locker, got %d runs", n)
+	}
+	if err := WithLocker(nil)(&Cron{}); err == nil {
+		t.Error("expected error for nil locker")
+	}
+}
+
+// TestMemoryLocker verifies that a released key is kept until its ttl expires
+func TestMemoryLocker(t *testing.T) {
+	l := NewMemoryLocker(20 * time.Millisecond)
+	ctx := context.Background()
+	won, release, err := l.Acquire(ctx, "job@1")
+	if !won || err != nil {
+		t.Fatalf("expected first acquire to win, got %v %v", won, err)
+	}
+	if won, _, _ := l.Acquire(ctx, "job@1"); won {
+		t.Error("expected held key to be refused")
+	}
+	if won, _, _ := l.Acquire(ctx, "job@2"); !won {
+		t.Error("expected a different key to be acquired")
+	}
+	release()
+	release()
+	if won, _, _ := l.Acquire(ctx, "job@1"); won {
+		t.Error("expected released key to be refused until it expires")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if won, _, _ := l.Acquire(ctx, "job@1"); !won {
+		t.Error("expected key to be available after the ttl")
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := l.Acquire(cancelled, "job@3"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context error, got %v", err)
+	}
+}
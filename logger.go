@@ -16,3 +16,64 @@ func (l *discardLogger) Info(msg string, keysAndValues ...any) {}
 
 // Error 实现Logger接口的Error方法
 func (l *discardLogger) Error(msg string, keysAndValues ...any) {}
+
+// MultiLogger 返回一个将日志转发给所有loggers的Logger
+// 按顺序转发，某个Logger panic时会被忽略，不影响其余Logger收到日志
+func MultiLogger(loggers ...Logger) Logger {
+	return multiLogger(loggers)
+}
+
+// multiLogger 是MultiLogger返回的Logger
+type multiLogger []Logger
+
+// Info 实现Logger接口的Info方法
+func (m multiLogger) Info(msg string, keysAndValues ...any) {
+	for _, l := range m {
+		guardLog(func() { l.Info(msg, keysAndValues...) })
+	}
+}
+
+// Error 实现Logger接口的Error方法
+func (m multiLogger) Error(msg string, keysAndValues ...any) {
+	for _, l := range m {
+		guardLog(func() { l.Error(msg, keysAndValues...) })
+	}
+}
+
+// guardLog 调用log并忽略其panic
+func guardLog(log func()) {
+	defer func() { _ = recover() }()
+	log()
+}
+
+// JobLogger 返回一个附带任务ID的Logger，任务名称非空时同时附带名称
+// 任务可以使用它输出日志，便于与调度器自身关于该任务的日志关联
+// 返回的Logger基于调度器当前的Logger，名称取自调用时的任务
+func (c *Cron) JobLogger(id EntryID) Logger {
+	kv := []any{"entry", id}
+	if e, ok := c.Entry(id); ok && e.Name != "" {
+		kv = append(kv, "name", e.Name)
+	}
+	return &scopedLogger{logger: c.logger, kv: kv}
+}
+
+// scopedLogger 在每条日志前附加固定的键值对
+type scopedLogger struct {
+	logger Logger
+	kv     []any
+}
+
+// Info 实现Logger接口的Info方法
+func (l *scopedLogger) Info(msg string, keysAndValues ...any) {
+	l.logger.Info(msg, l.with(keysAndValues)...)
+}
+
+// Error 实现Logger接口的Error方法
+func (l *scopedLogger) Error(msg string, keysAndValues ...any) {
+	l.logger.Error(msg, l.with(keysAndValues)...)
+}
+
+// with 返回附加了固定键值对的参数
+func (l *scopedLogger) with(keysAndValues []any) []any {
+	return append(l.kv[:len(l.kv):len(l.kv)], keysAndValues...)
+}
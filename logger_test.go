@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestJobLogger verifies that a job logger tags its output with the entry ID and name
+func TestJobLogger(t *testing.T) {
+	var out syncBuffer
+	c := New(WithLogger(slog.New(slog.NewTextHandler(&out, nil))))
+	diff, _ := c.ApplyConfig([]NamedSchedule{{Name: "report", Schedule: Every(time.Hour), Job: FuncJob(func() {})}})
+	named := diff.Added[0]
+	plain := c.AddFunc(Every(time.Hour), func() {})
+
+	c.JobLogger(named).Info("generated", "rows", 3)
+	c.JobLogger(plain).Error("failed")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	var generated, failed string
+	for _, line := range lines {
+		switch {
+		case strings.Contains(line, "msg=generated"):
+			generated = line
+		case strings.Contains(line, "msg=failed"):
+			failed = line
+		}
+	}
+	for _, want := range []string{"entry=1", "name=report", "rows=3"} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("expected %q in %q", want, generated)
+		}
+	}
+	if !strings.Contains(failed, "entry=2") || strings.Contains(failed, "name=") {
+		t.Errorf("expected entry=2 without a name in %q", failed)
+	}
+}
+
+// recordingLogger is a Logger keeping every message in memory
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...any)  { l.add("info " + msg) }
+func (l *recordingLogger) Error(msg string, keysAndValues ...any) { l.add("error " + msg) }
+
+func (l *recordingLogger) add(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, msg)
+}
+
+// panicLogger is a Logger that panics on every call
+type panicLogger struct{}
+
+func (panicLogger) Info(string, ...any)  { panic("info") }
+func (panicLogger) Error(string, ...any) { panic("error") }
+
+// TestMultiLogger verifies that every logger receives each message even if one panics
+func TestMultiLogger(t *testing.T) {
+	a, b := &recordingLogger{}, &recordingLogger{}
+	l := MultiLogger(a, panicLogger{}, b)
+	l.Info("started", "entry", 1)
+	l.Error("failed")
+
+	want := []string{"info started", "error failed"}
+	if !reflect.DeepEqual(a.messages, want) {
+		t.Errorf("expected first logger to receive %v, got %v", want, a.messages)
+	}
+	if !reflect.DeepEqual(b.messages, want) {
+		t.Errorf("expected second logger to receive %v, got %v", want, b.messages)
+	}
+}
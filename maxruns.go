@@ -0,0 +1,50 @@
+package cron
+
+// AddFuncN 添加一个最多按调度触发n次的定时任务
+// 第n次触发后任务不再被调度，但仍保留在任务列表中，可配合OnComplete在最后一次执行结束后清理
+// RunNow和依赖触发不计入次数，n小于1时不限制次数
+func (c *Cron) AddFuncN(schedule Schedule, n int, cmd func(), opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, FuncJob(cmd), append(opts, withMaxRuns(n))...)
+}
+
+// withMaxRuns 设置任务最多按调度触发的次数
+func withMaxRuns(n int) EntryOption {
+	return func(e *Entry) {
+		if n > 0 {
+			e.maxRuns = n
+		}
+	}
+}
+
+// OnComplete 设置任务的触发次数用完后的回调
+// fn在最后一次执行结束后、于执行任务的goroutine中调用，Stop返回的context会等待它完成
+// 只对AddFuncN等限制了次数的任务有效，fn的panic会被捕获并记录Error日志
+func OnComplete(fn func(EntryID)) EntryOption {
+	return func(e *Entry) {
+		e.onComplete = fn
+	}
+}
+
+// exhausted 返回任务是否已经用完触发次数
+func (e *Entry) exhausted() bool {
+	return e.maxRuns > 0 && e.runs >= e.maxRuns
+}
+
+// countRun 记录任务按调度触发了一次
+// 本次是最后一次且设置了OnComplete时，返回在执行结束后调用回调的函数，否则返回nil
+// 调用者需要持有entriesMu
+func (c *Cron) countRun(e *Entry) func() {
+	e.runs++
+	if !e.exhausted() || e.onComplete == nil {
+		return nil
+	}
+	id, fn := e.ID, e.onComplete
+	return func() {
+		defer func() {
+			if r := recover(); r != nil {
+				c.logger.Error("completion callback panic recovered", "entry", id, "error", r)
+			}
+		}()
+		fn(id)
+	}
+}
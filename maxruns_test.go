@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestAddFuncNOnComplete verifies that the completion callback fires once after the last allowed run
+func TestAddFuncNOnComplete(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	var runs, completions atomic.Int32
+	var completedAfter atomic.Int32
+	ran := make(chan struct{}, 5)
+	id := c.AddFuncN(Every(time.Minute), 3, func() {
+		runs.Add(1)
+		ran <- struct{}{}
+	}, OnComplete(func(EntryID) {
+		completedAfter.Store(runs.Load())
+		completions.Add(1)
+	}))
+	c.Start()
+
+	for i := range 5 {
+		clk.BlockUntil(1)
+		clk.Advance(time.Minute)
+		if i < 3 {
+			// wait for each run so the runs do not overlap
+			<-ran
+		}
+	}
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	if got := runs.Load(); got != 3 {
+		t.Errorf("expected 3 runs, got %d", got)
+	}
+	if got := completions.Load(); got != 1 {
+		t.Errorf("expected 1 completion callback, got %d", got)
+	}
+	if got := completedAfter.Load(); got != 3 {
+		t.Errorf("expected callback after the third run, got after %d runs", got)
+	}
+	e, ok := c.Entry(id)
+	if !ok {
+		t.Fatal("expected completed entry to remain registered")
+	}
+	if !e.Next.IsZero() {
+		t.Errorf("expected zero next for a completed entry, got %v", e.Next)
+	}
+}
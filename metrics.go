@@ -0,0 +1,82 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// DurationRecorder 定义了记录任务执行耗时的接口
+// 每次任务执行结束(包括返回错误和panic)后调用一次Record，
+// 适合对接Prometheus Histogram、statsd Timer等按任务区分的耗时统计
+// Record会在任务goroutine中被并发调用，实现需要保证并发安全
+type DurationRecorder interface {
+	Record(entryID EntryID, d time.Duration)
+}
+
+// Metrics 定义了任务执行的观测接口
+// 每次执行开始时调用JobStarted，正常结束(包括返回错误)时调用JobCompleted，panic时调用JobPanicked
+// 方法会在任务goroutine中被并发调用，实现需要保证并发安全
+// 对接Prometheus时，可以用以任务ID为标签的CounterVec实现JobStarted和JobPanicked，
+// 用HistogramVec实现JobCompleted，例如:
+//
+//	func (m promMetrics) JobCompleted(id cron.EntryID, d time.Duration) {
+//		m.duration.WithLabelValues(strconv.Itoa(int(id))).Observe(d.Seconds())
+//	}
+type Metrics interface {
+	JobStarted(id EntryID)
+	JobCompleted(id EntryID, d time.Duration)
+	JobPanicked(id EntryID)
+}
+
+// JobMetrics 是MemoryMetrics中单个任务的统计
+type JobMetrics struct {
+	Started   int           // 开始执行的次数
+	Completed int           // 正常结束的次数
+	Panicked  int           // panic的次数
+	Total     time.Duration // 正常结束的执行的总耗时
+}
+
+// MemoryMetrics 是在内存中累计统计的Metrics实现，主要用于测试
+type MemoryMetrics struct {
+	mu   sync.Mutex
+	jobs map[EntryID]JobMetrics
+}
+
+// NewMemoryMetrics 创建一个空的MemoryMetrics
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{jobs: make(map[EntryID]JobMetrics)}
+}
+
+// JobStarted 实现Metrics接口
+func (m *MemoryMetrics) JobStarted(id EntryID) {
+	m.update(id, func(j *JobMetrics) { j.Started++ })
+}
+
+// JobCompleted 实现Metrics接口
+func (m *MemoryMetrics) JobCompleted(id EntryID, d time.Duration) {
+	m.update(id, func(j *JobMetrics) {
+		j.Completed++
+		j.Total += d
+	})
+}
+
+// JobPanicked 实现Metrics接口
+func (m *MemoryMetrics) JobPanicked(id EntryID) {
+	m.update(id, func(j *JobMetrics) { j.Panicked++ })
+}
+
+// Job 返回指定任务当前的统计，没有记录时返回零值
+func (m *MemoryMetrics) Job(id EntryID) JobMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.jobs[id]
+}
+
+// update 在锁保护下修改指定任务的统计
+func (m *MemoryMetrics) update(id EntryID, fn func(*JobMetrics)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j := m.jobs[id]
+	fn(&j)
+	m.jobs[id] = j
+}
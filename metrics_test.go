@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingRecorder is a DurationRecorder that keeps every observation in memory
+type recordingRecorder struct {
+	mu           sync.Mutex
+	observations map[EntryID][]time.Duration
+}
+
+func (r *recordingRecorder) Record(id EntryID, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.observations == nil {
+		r.observations = make(map[EntryID][]time.Duration)
+	}
+	r.observations[id] = append(r.observations[id], d)
+}
+
+// TestDurationRecorder verifies that each run records one positive duration for its entry
+func TestDurationRecorder(t *testing.T) {
+	recorder := &recordingRecorder{}
+	c := New(WithDurationRecorder(recorder))
+	id := c.AddFunc(&TestSchedule{}, func() { time.Sleep(5 * time.Millisecond) })
+	other := c.AddFunc(&TestSchedule{}, func() {})
+
+	for i := 0; i < 3; i++ {
+		c.RunNow(id)
+	}
+	<-c.Stop().Done()
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if got := len(recorder.observations[id]); got != 3 {
+		t.Fatalf("expected 3 observations, got %d", got)
+	}
+	for _, d := range recorder.observations[id] {
+		if d < 5*time.Millisecond {
+			t.Errorf("expected duration of at least 5ms, got %v", d)
+		}
+	}
+	if got := len(recorder.observations[other]); got != 0 {
+		t.Errorf("expected no observations for entry that did not run, got %d", got)
+	}
+}
+
+// TestLastDuration verifies that an entry reports the duration of its latest run
+func TestLastDuration(t *testing.T) {
+	c := New()
+	id := c.AddFunc(&TestSchedule{}, func() { time.Sleep(50 * time.Millisecond) })
+	c.RunNow(id)
+	<-c.Stop().Done()
+
+	for _, e := range c.Entries() {
+		if e.ID != id {
+			continue
+		}
+		if e.LastDuration < 50*time.Millisecond || e.LastDuration > 150*time.Millisecond {
+			t.Errorf("expected last duration of about 50ms, got %v", e.LastDuration)
+		}
+		return
+	}
+	t.Fatal("entry not found")
+}
+
+// TestWithMetrics verifies that runs, durations and panics are reported per entry
+func TestWithMetrics(t *testing.T) {
+	m := NewMemoryMetrics()
+	c := New(WithMetrics(m))
+	slow := c.AddFunc(&TestSchedule{}, func() { time.Sleep(10 * time.Millisecond) })
+	broken := c.AddFunc(&TestSchedule{}, func() { panic("boom") })
+
+	c.RunNow(slow)
+	c.RunNow(slow)
+	c.RunNow(broken)
+	<-c.Stop().Done()
+
+	got := m.Job(slow)
+	if got.Started != 2 || got.Completed != 2 || got.Panicked != 0 {
+		t.Errorf("expected 2 started and completed runs, got %+v", got)
+	}
+	if got.Total < 20*time.Millisecond {
+		t.Errorf("expected at least 20ms of total duration, got %v", got.Total)
+	}
+	if got := m.Job(broken); got.Started != 1 || got.Completed != 0 || got.Panicked != 1 {
+		t.Errorf("expected a single panicked run, got %+v", got)
+	}
+	if got := m.Job(EntryID(999)); got != (JobMetrics{}) {
+		t.Errorf("expected zero metrics for an unknown entry, got %+v", got)
+	}
+}
+
+// TestWithMetricsNil verifies that a nil Metrics is rejected
+func TestWithMetricsNil(t *testing.T) {
+	if err := WithMetrics(nil)(&Cron{}); err == nil {
+		t.Error("expected error for nil metrics")
+	}
+}
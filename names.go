@@ -0,0 +1,97 @@
+package cron
+
+import "errors"
+
+// AddNamedFunc 添加一个带名称的函数作为定时任务
+// 名称不能为空，且不能与已有的任务重复，重复时返回ErrDuplicateName
+// 名称会出现在主循环关于该任务的日志中，之后可以通过EntryByName和RemoveByName按名称操作
+// 有名称的任务同样受ApplyConfig管理，配置中不存在的名称会被ApplyConfig删除
+func (c *Cron) AddNamedFunc(name string, schedule Schedule, cmd func(), opts ...EntryOption) (EntryID, error) {
+	if name == "" {
+		return 0, errors.New("entry name cannot be empty")
+	}
+	return c.addNamed(&Entry{Name: name, Schedule: schedule, Job: FuncJob(cmd)}, opts...)
+}
+
+// addNamed 添加一个有名称的任务，名称已存在时返回ErrDuplicateName
+func (c *Cron) addNamed(entry *Entry, opts ...EntryOption) (EntryID, error) {
+	for _, opt := range opts {
+		opt(entry)
+	}
+	if err := checkEntry(entry); err != nil {
+		return 0, err
+	}
+	c.bindLocation(entry.Schedule)
+	if err := c.checkInterval(entry.Schedule); err != nil {
+		return 0, err
+	}
+
+	// 与ApplyConfig相同，持有两把锁直接写入任务列表，保证名称检查和添加是原子的
+	c.runningMu.Lock()
+	defer c.runningMu.Unlock()
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if c.draining {
+		return 0, ErrDraining
+	}
+	if c.entryByName(entry.Name) != nil {
+		return 0, ErrDuplicateName
+	}
+	now := c.now()
+	c.nextID++
+	entry.ID = c.nextID
+	if c.running {
+		entry.Next = c.scheduleNext(entry, now)
+	}
+	c.appendEntry(entry)
+	c.logEntry("added", entry, "now", now, "entry", entry.ID, "next", entry.Next)
+	c.record(EventAdded, entry.ID, now, entry.Next)
+	if c.running {
+		c.wakeUp()
+	}
+	return entry.ID, nil
+}
+
+// EntryByName 返回指定名称任务的快照
+// 如果任务不存在，第二个返回值为false
+func (c *Cron) EntryByName(name string) (Entry, bool) {
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	if e := c.entryByName(name); e != nil {
+		return *e, true
+	}
+	return Entry{}, false
+}
+
+// RemoveByName 删除指定名称的任务，任务不存在时不做任何操作
+// 与Remove相同，调度器运行时会等待主循环完成删除，返回后任务已不在任务列表中
+func (c *Cron) RemoveByName(name string) {
+	c.entriesMu.RLock()
+	var id EntryID
+	if e := c.entryByName(name); e != nil {
+		id = e.ID
+	}
+	c.entriesMu.RUnlock()
+	if id != 0 {
+		c.Remove(id)
+	}
+}
+
+// entryByName 返回指定名称的任务，不存在时返回nil
+// 调用者需要持有entriesMu
+func (c *Cron) entryByName(name string) *Entry {
+	for _, e := range c.entries {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// logEntry 记录关于任务e的Info日志，任务名称非空时附带名称
+func (c *Cron) logEntry(msg string, e *Entry, keysAndValues ...any) {
+	if e.Name != "" {
+		keysAndValues = append(keysAndValues, "name", e.Name)
+	}
+	c.logger.Info(msg, keysAndValues...)
+}
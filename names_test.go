@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAddNamedFunc verifies that names are stored, looked up and kept unique
+func TestAddNamedFunc(t *testing.T) {
+	c := New()
+	id, err := c.AddNamedFunc("report", &TestSchedule{}, func() {})
+	if err != nil {
+		t.Fatalf("AddNamedFunc returned error: %v", err)
+	}
+	if _, err := c.AddNamedFunc("report", &TestSchedule{}, func() {}); !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("expected ErrDuplicateName, got %v", err)
+	}
+	if _, err := c.AddNamedFunc("", &TestSchedule{}, func() {}); err == nil {
+		t.Error("expected an error for an empty name")
+	}
+
+	e, ok := c.EntryByName("report")
+	if !ok || e.ID != id || e.Name != "report" {
+		t.Errorf("expected entry %d named report, got %+v (found %v)", id, e, ok)
+	}
+	if _, ok := c.EntryByName("missing"); ok {
+		t.Error("expected no entry for an unknown name")
+	}
+	if n := len(c.Entries()); n != 1 {
+		t.Errorf("expected rejected names not to add entries, got %d", n)
+	}
+}
+
+// TestRemoveByName verifies that a named entry can be removed while the scheduler runs and its name reused
+func TestRemoveByName(t *testing.T) {
+	c := New()
+	c.AddNamedFunc("cleanup", &TestSchedule{}, func() {})
+	c.Start()
+	defer c.Stop()
+
+	c.RemoveByName("cleanup")
+	c.RemoveByName("missing")
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := c.EntryByName("cleanup"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("named entry was not removed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	id, err := c.AddNamedFunc("cleanup", &TestSchedule{}, func() {})
+	if err != nil {
+		t.Fatalf("expected the name to be reusable after removal, got %v", err)
+	}
+	if e, ok := c.Entry(id); !ok || e.Next.IsZero() {
+		t.Errorf("expected an entry added while running to be scheduled, got %+v", e)
+	}
+}
+
+// TestNamedEntryLogs verifies that run loop log lines carry the entry name
+func TestNamedEntryLogs(t *testing.T) {
+	var out syncBuffer
+	c := New(WithLogger(slog.New(slog.NewTextHandler(&out, nil))))
+	c.AddNamedFunc("nightly", &TestSchedule{}, func() {})
+	c.Start()
+	<-c.Stop().Done()
+
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.Contains(line, "msg=schedule") {
+			if !strings.Contains(line, "name=nightly") {
+				t.Errorf("expected the schedule log to include the name, got %q", line)
+			}
+			return
+		}
+	}
+	t.Errorf("expected a schedule log line, got %q", out.String())
+}
@@ -0,0 +1,21 @@
+package cron
+
+import "time"
+
+// notifyNextChange 在新的goroutine中调用最早执行时间变化的回调，不会阻塞主循环
+// 回调串行执行，且序号较旧的通知在较新的通知之后到达时会被丢弃，
+// 因此回调看到的最早执行时间总是单调更新的
+func (c *Cron) notifyNextChange(next time.Time, id EntryID) {
+	seq := c.nextChangeSeq.Add(1)
+	c.loopWaiter.Add(1)
+	go func() {
+		defer c.loopWaiter.Done()
+		c.nextChangeMu.Lock()
+		defer c.nextChangeMu.Unlock()
+		if seq < c.nextDelivered {
+			return
+		}
+		c.nextDelivered = seq
+		c.onNextChange(next, id)
+	}()
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestNextChangeCallback verifies that adding a sooner job reports the new head of the schedule
+func TestNextChangeCallback(t *testing.T) {
+	type change struct {
+		next time.Time
+		id   EntryID
+	}
+	changes := make(chan change, 10)
+	c := New(WithNextChangeCallback(func(next time.Time, id EntryID) {
+		changes <- change{next, id}
+	}))
+	hourly := c.AddFunc(Every(time.Hour), func() {})
+	c.Start()
+	defer c.Stop()
+
+	wait := func(want EntryID) change {
+		t.Helper()
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case ch := <-changes:
+				if ch.id == want {
+					return ch
+				}
+			case <-timeout:
+				t.Fatalf("no change reported for entry %d", want)
+			}
+		}
+	}
+
+	first := wait(hourly)
+	if until := time.Until(first.next); until < 59*time.Minute {
+		t.Errorf("expected hourly head about an hour away, got %v", until)
+	}
+
+	sooner := c.AddFunc(Every(time.Minute), func() {})
+	second := wait(sooner)
+	if !second.next.Before(first.next) {
+		t.Errorf("expected new head %v before previous head %v", second.next, first.next)
+	}
+}
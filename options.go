@@ -34,3 +34,381 @@ func WithLogger(logger Logger) Option {
 		return nil
 	}
 }
+
+// WithPanicPolicy 设置任务panic时的处理策略
+// 默认为PanicRecover
+func WithPanicPolicy(policy PanicPolicy) Option {
+	return func(c *Cron) error {
+		c.panicPolicy = policy
+		return nil
+	}
+}
+
+// WithAbortFunc 设置CrashAfter策略达到阈值时调用的中止函数
+// 参数abort接收触发中止的panic值，不能为nil
+func WithAbortFunc(abort func(recovered any)) Option {
+	return func(c *Cron) error {
+		if abort == nil {
+			return errors.New("abort func cannot be nil")
+		}
+		c.abort = abort
+		return nil
+	}
+}
+
+// WithErrorHandler 设置任务返回非nil错误时调用的处理函数
+// handler接收任务ID和错误，在任务所在的goroutine中调用，错误仍会记录到Logger
+// 只处理ErrorJob等返回的错误，任务panic由panic策略处理；普通Job不会触发handler
+// 参数handler不能为nil
+func WithErrorHandler(handler func(EntryID, error)) Option {
+	return func(c *Cron) error {
+		if handler == nil {
+			return errors.New("error handler cannot be nil")
+		}
+		c.errorHandler = handler
+		return nil
+	}
+}
+
+// WithRecoverHandler 设置任务panic时调用的处理函数，代替默认的Error日志
+// handler接收任务ID、恢复的值和debug.Stack()取得的调用栈，适合上报到Sentry等错误追踪系统
+// 对所有任务生效，panic策略照常应用；只需处理单个任务时使用WithPanicHandler包装任务
+// 参数handler不能为nil
+func WithRecoverHandler(handler func(id EntryID, recovered any, stack []byte)) Option {
+	return func(c *Cron) error {
+		if handler == nil {
+			return errors.New("recover handler cannot be nil")
+		}
+		c.recoverHandler = handler
+		return nil
+	}
+}
+
+// WithDurationRecorder 设置记录任务执行耗时的DurationRecorder
+// 参数recorder不能为nil
+func WithDurationRecorder(recorder DurationRecorder) Option {
+	return func(c *Cron) error {
+		if recorder == nil {
+			return errors.New("duration recorder cannot be nil")
+		}
+		c.recorder = recorder
+		return nil
+	}
+}
+
+// WithMetrics 设置接收任务执行次数、耗时和panic的Metrics
+// 参数m不能为nil
+func WithMetrics(m Metrics) Option {
+	return func(c *Cron) error {
+		if m == nil {
+			return errors.New("metrics cannot be nil")
+		}
+		c.metrics = m
+		return nil
+	}
+}
+
+// EntryOption 定义用于配置单个任务的函数选项类型
+// 在AddFunc、AddJob等方法中传入，只影响被添加的任务
+type EntryOption func(*Entry)
+
+// WithShardInfo 设置当前实例在集群中负责的分片
+// 参数index为当前实例的分片序号，total为分片总数，要求0 <= index < total
+// 只有通过WithShardKey声明了分片键的任务会受影响
+func WithShardInfo(index, total int) Option {
+	return func(c *Cron) error {
+		if total < 1 {
+			return errors.New("shard total must be positive")
+		}
+		if index < 0 || index >= total {
+			return errors.New("shard index out of range")
+		}
+		c.shardIndex = index
+		c.shardTotal = total
+		return nil
+	}
+}
+
+// WithShardKey 为任务声明分片键
+// 配合WithShardInfo使用，任务只在hash(key) % total == index的实例上触发，
+// 使多副本部署时每个任务只在一个副本上执行；未声明分片键的任务在所有实例上触发
+func WithShardKey(key string) EntryOption {
+	return func(e *Entry) {
+		e.shardKey = key
+	}
+}
+
+// WithValidateOnStart 在Start和Run时先调用Validate检查所有任务
+// 检查失败时记录Error日志并放弃启动
+func WithValidateOnStart() Option {
+	return func(c *Cron) error {
+		c.validateOnStart = true
+		return nil
+	}
+}
+
+// WithExclusive 将任务声明为独占任务
+// 独占任务执行期间，其他到期的任务会等待它完成后再开始；普通任务之间仍可并发执行
+// 适用于修改其他任务共享状态的任务
+func WithExclusive() EntryOption {
+	return func(e *Entry) {
+		e.exclusive = true
+	}
+}
+
+// WithGroup 将任务加入名为name的互斥组，同一组的任务不会同时执行
+// 组内任务到期时如果同组的其他任务正在执行，会等待其完成后再开始；与WithExclusive不同，不影响组外的任务
+// 适用于访问同一外部资源的多个任务，是单进程内代替分布式锁的轻量方案；name为空时不加入任何组
+func WithGroup(name string) EntryOption {
+	return func(e *Entry) {
+		e.group = name
+	}
+}
+
+// WithEventBuffer 启用调度决策事件的环形缓冲区，最多保留size个最近的事件
+// 参数size必须为正数，事件可通过RecentEvents读取
+func WithEventBuffer(size int) Option {
+	return func(c *Cron) error {
+		if size <= 0 {
+			return errors.New("event buffer size must be positive")
+		}
+		c.events = newEventRing(size)
+		return nil
+	}
+}
+
+// WithMinInterval 设置允许的最小调度间隔
+// 添加任务时会采样调度器的若干次执行时间，相邻两次执行间隔短于d的任务会被拒绝，
+// 用于在多租户环境中防止过于频繁的调度占用共享资源
+func WithMinInterval(d time.Duration) Option {
+	return func(c *Cron) error {
+		if d < 0 {
+			return errors.New("min interval cannot be negative")
+		}
+		c.minInterval = d
+		return nil
+	}
+}
+
+// WithIntervalFloor 设置固定间隔调度的安全下限，默认为10ms
+// Every和FixedDelay的间隔短于d时，添加任务仍会成功，但会记录Error日志提示可能无法准时触发；
+// d为0时不检查。需要拒绝过短间隔时使用WithMinInterval
+func WithIntervalFloor(d time.Duration) Option {
+	return func(c *Cron) error {
+		if d < 0 {
+			return errors.New("interval floor cannot be negative")
+		}
+		c.intervalFloor = d
+		return nil
+	}
+}
+
+// WithJobRegistry 设置LoadFromConfig按名称创建任务时使用的注册表
+func WithJobRegistry(r *JobRegistry) Option {
+	return func(c *Cron) error {
+		if r == nil {
+			return errors.New("job registry cannot be nil")
+		}
+		c.registry = r
+		return nil
+	}
+}
+
+// WithArchiveRemoved 保留被删除任务的归档而不是直接丢弃
+// 归档可通过Archived读取，用于审计最近被取消的任务及其删除时间
+// 默认最多保留100个任务，可通过WithArchiveLimit调整
+func WithArchiveRemoved() Option {
+	return func(c *Cron) error {
+		c.archive = true
+		return nil
+	}
+}
+
+// WithArchiveLimit 设置归档最多保留的任务数，超出时丢弃最早删除的任务
+// 参数limit必须为正数
+func WithArchiveLimit(limit int) Option {
+	return func(c *Cron) error {
+		if limit <= 0 {
+			return errors.New("archive limit must be positive")
+		}
+		c.archiveLimit = limit
+		return nil
+	}
+}
+
+// WithNextChangeCallback 设置最早执行时间变化时的回调
+// 主循环在添加、删除、触发或调整任务后发现最早执行的任务或其时间发生变化时调用fn，
+// 没有待执行的任务时next为零值、id为0
+// fn在独立的goroutine中串行调用，不会阻塞主循环，过时的通知会被丢弃
+func WithNextChangeCallback(fn func(next time.Time, id EntryID)) Option {
+	return func(c *Cron) error {
+		if fn == nil {
+			return errors.New("next change callback cannot be nil")
+		}
+		c.onNextChange = fn
+		return nil
+	}
+}
+
+// WithHardDeadlineLog 为每次任务执行设置截止时间d
+// 到达截止时间时取消任务的context并记录Error日志；如果任务在随后与d等长的宽限期内仍未结束，
+// 累加Stats().LeakedJobs并记录Error日志
+// Go无法强制终止goroutine，忽略取消的任务会一直运行下去，此选项用于发现这类任务而不是终止它们
+func WithHardDeadlineLog(d time.Duration) Option {
+	return func(c *Cron) error {
+		if d <= 0 {
+			return errors.New("hard deadline must be positive")
+		}
+		c.hardDeadline = d
+		return nil
+	}
+}
+
+// WithLeaderCheck 设置判断当前实例是否为leader的函数，多个实例部署时只有leader执行任务
+// 主循环每次计算定时器和触发任务前调用fn，fn返回false时不执行任何任务，到期的任务保持到期状态
+// 非leader时主循环不会定时醒来，leader身份变化后应调用WakeNow让调度器重新判断
+// fn在主循环中调用，应当快速返回
+func WithLeaderCheck(fn func() bool) Option {
+	return func(c *Cron) error {
+		if fn == nil {
+			return errors.New("leader check cannot be nil")
+		}
+		c.isLeader = fn
+		return nil
+	}
+}
+
+// WithLocker 设置多实例部署时使用的分布式锁
+// 设置后每次按调度触发任务前，执行任务的goroutine会以任务名称(无名称时为ID)和计划时间为键取得锁，
+// 只有取得锁的实例执行该次任务，其他实例记录reason为"locked"的跳过日志；RunNow和依赖任务不加锁
+// 无名称的任务需要在各实例上以相同的顺序添加才能得到相同的键
+// Every、FixedDelay和RandomWithin的计划时间按间隔截断到所在周期后作为键，各实例在每个周期内只执行一次；
+// 附加了抖动时计划时间靠近周期边界的执行可能落入相邻周期
+// After、WeightedSchedule等计划时间只由各实例自身决定且没有固定周期的调度器无法在实例之间对齐，不应与锁一起使用
+func WithLocker(l Locker) Option {
+	return func(c *Cron) error {
+		if l == nil {
+			return errors.New("locker cannot be nil")
+		}
+		c.locker = l
+		return nil
+	}
+}
+
+// WithClock 设置调度器使用的时钟，默认使用系统时钟
+// 测试中可以注入FakeClock，手动推进时间而无需真实等待
+func WithClock(clk Clock) Option {
+	return func(c *Cron) error {
+		if clk == nil {
+			return errors.New("clock cannot be nil")
+		}
+		c.clock = clk
+		return nil
+	}
+}
+
+// WithMissedPolicy 设置任务错过多次执行时的处理策略，默认为SkipMissed
+// CatchUp策略不限制补跑次数，停顿很久的高频任务会一次触发大量执行，需要限制时使用WithCatchUpLimit
+func WithMissedPolicy(p MissedPolicy) Option {
+	return func(c *Cron) error {
+		if p != SkipMissed && p != CatchUp {
+			return errors.New("invalid missed policy")
+		}
+		c.missedPolicy = p
+		return nil
+	}
+}
+
+// WithCatchUpLimit 启用有上限的补跑，相当于使用CatchUp策略并限制补跑范围
+// 调度器长时间停顿后醒来时，任务会为错过的每次计划时间各执行一次，
+// 但只补跑最近within时间内错过的执行，且最多maxRuns次，更早的错过会被丢弃
+// 所有错过的执行都早于within时，本次不执行并记录reason为"catch-up"的跳过日志
+// 未设置时长时间停顿后只执行一次
+func WithCatchUpLimit(maxRuns int, within time.Duration) Option {
+	return func(c *Cron) error {
+		if maxRuns <= 0 {
+			return errors.New("catch-up max runs must be positive")
+		}
+		if within <= 0 {
+			return errors.New("catch-up window must be positive")
+		}
+		c.missedPolicy = CatchUp
+		c.catchUpMax = maxRuns
+		c.catchUpWithin = within
+		return nil
+	}
+}
+
+// WithSlowScheduleThreshold 设置Schedule.Next的耗时阈值
+// 主循环计算下次执行时间的耗时超过d时记录带有任务ID的Error日志
+// Next在主循环中同步调用，耗时过长会推迟所有任务，可用于发现需要优化的自定义调度器
+func WithSlowScheduleThreshold(d time.Duration) Option {
+	return func(c *Cron) error {
+		if d <= 0 {
+			return errors.New("slow schedule threshold must be positive")
+		}
+		c.slowSchedule = d
+		return nil
+	}
+}
+
+// WithRand 设置调度器使用的随机数源，默认使用全局随机源
+// 测试中可注入固定种子的随机源以获得确定的结果
+// 调度器在使用时会加锁，传入的随机数源不需要是并发安全的
+func WithRand(r Rand) Option {
+	return func(c *Cron) error {
+		if r == nil {
+			return errors.New("rand cannot be nil")
+		}
+		c.rand = r
+		return nil
+	}
+}
+
+// WithAutoJitter 为所有基于DelaySchedule的任务自动附加随机延迟
+// 主循环每次计算下次执行时间后附加[0, fraction*Delay)的随机延迟，使多个实例上的周期任务错开执行
+// fraction必须满足0 <= fraction < 1，为0时不附加延迟
+func WithAutoJitter(fraction float64) Option {
+	return func(c *Cron) error {
+		if !(fraction >= 0 && fraction < 1) {
+			return errors.New("auto jitter fraction must be in [0, 1)")
+		}
+		c.autoJitter = fraction
+		return nil
+	}
+}
+
+// WithMaxConcurrent 限制同时执行的任务数不超过n，保护下游资源
+// 达到上限时新触发的任务等待空闲的名额，配合WithSkipWhenFull可改为直接跳过
+// 限制对调度触发、RunNow和依赖任务都生效，DrainBacklog使用自己的并发度
+// n必须为正数
+func WithMaxConcurrent(n int) Option {
+	return func(c *Cron) error {
+		if n < 1 {
+			return errors.New("max concurrent must be positive")
+		}
+		c.slots = make(chan struct{}, n)
+		return nil
+	}
+}
+
+// WithSkipWhenFull 使达到WithMaxConcurrent上限时新触发的任务直接跳过，而不是等待空闲的名额
+// 跳过时记录reason为"max-concurrent"的日志，未设置WithMaxConcurrent时没有效果
+func WithSkipWhenFull() Option {
+	return func(c *Cron) error {
+		c.skipWhenFull = true
+		return nil
+	}
+}
+
+// WithOutputLimit 设置Entry.LastOutput保留的最大字节数，默认为256
+// 超出的部分会被截断，不会截断多字节字符
+func WithOutputLimit(n int) Option {
+	return func(c *Cron) error {
+		if n <= 0 {
+			return errors.New("output limit must be positive")
+		}
+		c.outputLimit = n
+		return nil
+	}
+}
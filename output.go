@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+// defaultOutputLimit LastOutput默认保留的最大字节数
+const defaultOutputLimit = 256
+
+// StringJob 是返回一行可读输出的任务，例如检查状态的任务
+// 通过AddStringJob添加后，每次执行的输出保存在Entry.LastOutput中
+type StringJob interface {
+	Run() string
+}
+
+// StringFuncJob 将返回字符串的函数转换为StringJob接口实现
+type StringFuncJob func() string
+
+// Run 实现StringJob接口
+func (f StringFuncJob) Run() string { return f() }
+
+// AddStringJob 添加一个返回输出的任务，每次执行后将输出保存到LastOutput
+func (c *Cron) AddStringJob(schedule Schedule, cmd StringJob, opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, stringJob{job: cmd, c: c}, opts...)
+}
+
+// stringJob 将StringJob适配为Job，并保存其输出
+type stringJob struct {
+	job StringJob
+	c   *Cron
+}
+
+// Run 实现Job接口，直接调用时输出不会被保存
+func (j stringJob) Run() {
+	j.job.Run()
+}
+
+// isNil 实现nilChecker接口
+func (j stringJob) isNil() bool {
+	return isNilJob(j.job)
+}
+
+// runContext 执行任务并保存输出
+func (j stringJob) runContext(ctx context.Context) error {
+	j.c.setLastOutput(entryIDFrom(ctx), j.job.Run())
+	return nil
+}
+
+// setLastOutput 截断输出后保存到任务的LastOutput，任务已被删除时忽略
+func (c *Cron) setLastOutput(id EntryID, out string) {
+	out = truncate(out, c.outputLimit)
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if e := c.entry(id); e != nil {
+		e.LastOutput = out
+	}
+}
+
+// truncate 将s截断为不超过n字节，不会截断多字节字符
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
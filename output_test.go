@@ -0,0 +1,22 @@
+package cron
+
+import "testing"
+
+// TestStringJobOutput verifies that the latest output is kept and truncated
+func TestStringJobOutput(t *testing.T) {
+	c := New(WithOutputLimit(8))
+	short := c.AddStringJob(&TestSchedule{}, StringFuncJob(func() string { return "ok" }))
+	long := c.AddStringJob(&TestSchedule{}, StringFuncJob(func() string { return "status: degraded" }))
+	wide := c.AddStringJob(&TestSchedule{}, StringFuncJob(func() string { return "状态正常" }))
+	c.RunNow(short)
+	c.RunNow(long)
+	c.RunNow(wide)
+	<-c.Stop().Done()
+
+	want := map[EntryID]string{short: "ok", long: "status: ", wide: "状态"}
+	for _, e := range c.Entries() {
+		if e.LastOutput != want[e.ID] {
+			t.Errorf("expected output %q for entry %d, got %q", want[e.ID], e.ID, e.LastOutput)
+		}
+	}
+}
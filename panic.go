@@ -0,0 +1,126 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicPolicy 定义任务panic时调度器的处理策略
+// 可选值为PanicRecover(默认)、PanicCount和CrashAfter(n)
+type PanicPolicy struct {
+	count      bool // 是否计入Stats().Panics
+	crashAfter int  // 累计panic次数达到该值时中止进程，0表示不中止
+}
+
+var (
+	// PanicRecover 捕获panic并记录Error日志，调度器继续运行
+	PanicRecover = PanicPolicy{}
+	// PanicCount 在PanicRecover的基础上累计panic次数，可通过Stats().Panics读取
+	PanicCount = PanicPolicy{count: true}
+)
+
+// CrashAfter 返回一个累计panic次数，并在次数达到n时中止进程的策略
+// 中止时调用WithAbortFunc设置的函数，未设置时在任务goroutine中重新panic使进程退出，
+// 以便编排系统重启处于异常状态的服务
+// n小于1时等同于PanicCount
+func CrashAfter(n int) PanicPolicy {
+	if n < 1 {
+		return PanicCount
+	}
+	return PanicPolicy{count: true, crashAfter: n}
+}
+
+// handlePanic 按照panic策略处理已捕获的panic
+// 设置了WithRecoverHandler时交给处理函数，否则记录Error日志；之后照常应用panic策略
+// 需要在recover所在的defer中调用，才能取得panic发生处的调用栈
+func (c *Cron) handlePanic(id EntryID, recovered any) {
+	if c.recoverHandler != nil {
+		c.callRecoverHandler(id, recovered, debug.Stack())
+	} else {
+		c.logger.Error("job panic recovered", "entry", id, "error", recovered)
+	}
+	if !c.panicPolicy.count {
+		return
+	}
+	n := c.panics.Add(1)
+	if c.panicPolicy.crashAfter > 0 && n >= int64(c.panicPolicy.crashAfter) {
+		c.logger.Error("panic threshold reached, aborting", "entry", id, "panics", n)
+		c.abort(recovered)
+	}
+}
+
+// callRecoverHandler 调用WithRecoverHandler设置的处理函数，处理函数自身panic时记录日志
+func (c *Cron) callRecoverHandler(id EntryID, recovered any, stack []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.Error("recover handler panic", "entry", id, "error", recovered, "handler", r)
+		}
+	}()
+	c.recoverHandler(id, recovered, stack)
+}
+
+// defaultAbort 是默认的中止函数，重新panic使进程退出
+func defaultAbort(recovered any) {
+	panic(fmt.Sprintf("cron: panic threshold reached: %v", recovered))
+}
+
+// entryIDKey 是任务上下文中保存任务ID的键
+type entryIDKey struct{}
+
+// entryIDFrom 返回runJob保存在上下文中的任务ID
+func entryIDFrom(ctx context.Context) EntryID {
+	id, _ := ctx.Value(entryIDKey{}).(EntryID)
+	return id
+}
+
+// cronKey 是任务上下文中保存执行该任务的调度器的键
+type cronKey struct{}
+
+// cronFrom 返回runJob保存在上下文中的调度器，直接调用Job.Run时返回nil
+func cronFrom(ctx context.Context) *Cron {
+	c, _ := ctx.Value(cronKey{}).(*Cron)
+	return c
+}
+
+// WithPanicHandler 返回一个为单个任务定制panic处理的JobWrapper
+// 任务panic时调用handler处理恢复的值，代替调度器默认的日志和panic策略，本次执行视为失败
+// handler自身panic时，原始的panic会交给调度器按默认方式处理
+// 直接调用Job.Run而不经过调度器时，handler收到的任务ID为0
+func WithPanicHandler(handler func(EntryID, any)) JobWrapper {
+	return func(j Job) Job {
+		return &panicHandlerJob{job: j, handler: handler}
+	}
+}
+
+// panicHandlerJob 是WithPanicHandler返回的任务
+type panicHandlerJob struct {
+	job     Job
+	handler func(EntryID, any)
+}
+
+// Run 实现Job接口
+func (j *panicHandlerJob) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// runContext 执行任务并将panic交给handler处理
+func (j *panicHandlerJob) runContext(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			j.handle(entryIDFrom(ctx), r)
+			err = fmt.Errorf("job panic: %v", r)
+		}
+	}()
+	return invoke(ctx, j.job)
+}
+
+// handle 调用handler，handler panic时重新抛出原始的panic
+func (j *panicHandlerJob) handle(id EntryID, recovered any) {
+	defer func() {
+		if recover() != nil {
+			panic(recovered)
+		}
+	}()
+	j.handler(id, recovered)
+}
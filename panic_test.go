@@ -0,0 +1,156 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCrashAfterInvokesAbort verifies that CrashAfter(1) calls the abort hook after a single panic
+func TestCrashAfterInvokesAbort(t *testing.T) {
+	aborted := make(chan any, 1)
+	c := New(
+		WithPanicPolicy(CrashAfter(1)),
+		WithAbortFunc(func(recovered any) { aborted <- recovered }),
+	)
+	id := c.AddFunc(&TestSchedule{}, func() { panic("boom") })
+
+	if err := c.RunNow(id); err != nil {
+		t.Fatalf("RunNow returned error: %v", err)
+	}
+	select {
+	case r := <-aborted:
+		if r != "boom" {
+			t.Errorf("expected recovered value %q, got %v", "boom", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("abort hook was not invoked")
+	}
+}
+
+// TestPanicCount verifies that PanicCount counts panics without aborting
+func TestPanicCount(t *testing.T) {
+	c := New(
+		WithPanicPolicy(PanicCount),
+		WithAbortFunc(func(any) { t.Error("abort should not be called with PanicCount") }),
+	)
+	id := c.AddFunc(&TestSchedule{}, func() { panic("boom") })
+
+	for i := 0; i < 3; i++ {
+		c.RunNow(id)
+	}
+	<-c.Stop().Done()
+
+	if got := c.Stats().Panics; got != 3 {
+		t.Errorf("expected 3 counted panics, got %d", got)
+	}
+}
+
+// TestPanicRecoverDefault verifies that the default policy neither counts nor aborts
+func TestPanicRecoverDefault(t *testing.T) {
+	c := New(WithAbortFunc(func(any) { t.Error("abort should not be called by default") }))
+	id := c.AddFunc(&TestSchedule{}, func() { panic("boom") })
+
+	c.RunNow(id)
+	<-c.Stop().Done()
+
+	if got := c.Stats().Panics; got != 0 {
+		t.Errorf("expected no counted panics, got %d", got)
+	}
+}
+
+// TestWithPanicHandler verifies that a per-entry handler receives the panic and the scheduler keeps running
+func TestWithPanicHandler(t *testing.T) {
+	type handled struct {
+		id EntryID
+		r  any
+	}
+	got := make(chan handled, 1)
+	c := New(WithPanicPolicy(PanicCount))
+	job := NewChain(WithPanicHandler(func(id EntryID, r any) { got <- handled{id, r} })).
+		Then(FuncJob(func() { panic("boom") }))
+	id := c.AddJob(&TestSchedule{}, job)
+	ran := make(chan struct{}, 1)
+	other := c.AddFunc(&TestSchedule{}, func() { ran <- struct{}{} })
+	c.Start()
+	defer c.Stop()
+
+	c.RunNow(id)
+	select {
+	case h := <-got:
+		if h.id != id || h.r != "boom" {
+			t.Errorf("expected handler call for entry %d with %q, got %+v", id, "boom", h)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("panic handler was not invoked")
+	}
+
+	c.RunNow(other)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler stopped running jobs after a handled panic")
+	}
+	if n := c.Stats().Panics; n != 0 {
+		t.Errorf("expected handled panic to bypass the default policy, got %d counted", n)
+	}
+}
+
+// TestWithPanicHandlerPanics verifies that a panicking handler falls back to the default policy
+func TestWithPanicHandlerPanics(t *testing.T) {
+	c := New(WithPanicPolicy(PanicCount))
+	job := WithPanicHandler(func(EntryID, any) { panic("handler") })(FuncJob(func() { panic("boom") }))
+	id := c.AddJob(&TestSchedule{}, job)
+
+	c.RunNow(id)
+	<-c.Stop().Done()
+
+	if n := c.Stats().Panics; n != 1 {
+		t.Errorf("expected the original panic to be counted once, got %d", n)
+	}
+}
+
+// TestWithRecoverHandler verifies that the scheduler-wide handler receives the panic with a stack trace
+func TestWithRecoverHandler(t *testing.T) {
+	type report struct {
+		id    EntryID
+		r     any
+		stack []byte
+	}
+	got := make(chan report, 1)
+	logger := &recordingLogger{}
+	c := New(WithLogger(logger), WithPanicPolicy(PanicCount), WithRecoverHandler(func(id EntryID, r any, stack []byte) {
+		got <- report{id, r, stack}
+	}))
+	id := c.AddFunc(&TestSchedule{}, func() { panic("boom") })
+
+	c.RunNow(id)
+	<-c.Stop().Done()
+
+	select {
+	case rep := <-got:
+		if rep.id != id || rep.r != "boom" {
+			t.Errorf("expected entry %d with %q, got %d with %v", id, "boom", rep.id, rep.r)
+		}
+		if !strings.Contains(string(rep.stack), "TestWithRecoverHandler") {
+			t.Errorf("expected a stack trace including the panicking job, got %s", rep.stack)
+		}
+	default:
+		t.Fatal("recover handler was not invoked")
+	}
+	if n := c.Stats().Panics; n != 1 {
+		t.Errorf("expected the panic policy to still count the panic, got %d", n)
+	}
+	for _, m := range logger.messages {
+		if m == "error job panic recovered" {
+			t.Error("expected the handler to replace the default panic log")
+		}
+	}
+}
+
+// TestWithRecoverHandlerNil verifies that a nil handler is rejected
+func TestWithRecoverHandlerNil(t *testing.T) {
+	if err := WithRecoverHandler(nil)(&Cron{}); err == nil {
+		t.Error("expected error for nil handler")
+	}
+}
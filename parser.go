@@ -0,0 +1,163 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bounds 描述cron表达式中一个字段的名称和取值范围
+type bounds struct {
+	name     string
+	min, max uint
+}
+
+var (
+	secondBounds = bounds{"second", 0, 59}
+	minuteBounds = bounds{"minute", 0, 59}
+	hourBounds   = bounds{"hour", 0, 23}
+	domBounds    = bounds{"day of month", 1, 31}
+	monthBounds  = bounds{"month", 1, 12}
+	dowBounds    = bounds{"day of week", 0, 6}
+)
+
+// Parse 解析标准的5字段cron表达式，返回对应的调度器
+// 字段依次为: 分钟 小时 日期 月份 星期(0=周日)
+// 每个字段支持:
+//
+//   - 任意值
+//     5        单个值
+//     1-5      范围
+//     1,3,5    列表
+//     */10     步长，也可以与范围或单个值组合，如 10-50/10、5/15
+//
+// 例如: Parse("*/5 * * * *")每5分钟执行一次
+// 表达式不合法(字段数量错误、取值越界等)时返回错误
+func Parse(spec string) (Schedule, error) {
+	return parseSpec(spec, false)
+}
+
+// ParseWithSeconds 解析以秒为第一个字段的6字段cron表达式，返回对应的调度器
+// 字段依次为: 秒 分钟 小时 日期 月份 星期，每个字段的语法与Parse相同
+// 例如: ParseWithSeconds("*/30 * * * * *")每30秒执行一次
+func ParseWithSeconds(spec string) (Schedule, error) {
+	return parseSpec(spec, true)
+}
+
+// parseSpec 解析5字段或带秒的6字段cron表达式，5字段时秒固定为0
+func parseSpec(spec string, withSeconds bool) (Schedule, error) {
+	want := 5
+	if withSeconds {
+		want = 6
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != want {
+		return nil, fmt.Errorf("invalid cron spec %q: expected %d fields, found %d", spec, want, len(fields))
+	}
+	if !withSeconds {
+		fields = append([]string{"0"}, fields...)
+	}
+
+	s := &SpecSchedule{}
+	for i, f := range []struct {
+		bits *uint64
+		b    bounds
+	}{
+		{&s.Second, secondBounds},
+		{&s.Minute, minuteBounds},
+		{&s.Hour, hourBounds},
+		{&s.Dom, domBounds},
+		{&s.Month, monthBounds},
+		{&s.Dow, dowBounds},
+	} {
+		bits, err := parseField(fields[i], f.b)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron spec %q: %w", spec, err)
+		}
+		*f.bits = bits
+	}
+	return s, nil
+}
+
+// parseField 解析一个以逗号分隔的字段，返回允许取值的位图
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, expr := range strings.Split(field, ",") {
+		r, err := parseRange(expr, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= r
+	}
+	return bits, nil
+}
+
+// parseRange 解析形如 *、N、N-M、*/S、N/S、N-M/S 的单个表达式
+func parseRange(expr string, b bounds) (uint64, error) {
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("%s: too many slashes in %q", b.name, expr)
+	}
+
+	var start, end uint
+	var extra uint64
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	switch {
+	case rangeAndStep[0] == "*":
+		start, end = b.min, b.max
+		extra = starBit
+	case len(lowAndHigh) == 1:
+		v, err := parseValue(lowAndHigh[0], b)
+		if err != nil {
+			return 0, err
+		}
+		start, end = v, v
+	case len(lowAndHigh) == 2:
+		var err error
+		if start, err = parseValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(lowAndHigh[1], b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("%s: range start %d beyond end %d in %q", b.name, start, end, expr)
+		}
+	default:
+		return 0, fmt.Errorf("%s: too many hyphens in %q", b.name, expr)
+	}
+
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		n, err := strconv.ParseUint(rangeAndStep[1], 10, 0)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("%s: invalid step %q in %q", b.name, rangeAndStep[1], expr)
+		}
+		step = uint(n)
+		// N/S 表示从N开始到最大值，每隔S取一次
+		if len(lowAndHigh) == 1 && rangeAndStep[0] != "*" {
+			end = b.max
+		}
+		if step > 1 {
+			extra = 0
+		}
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << v
+	}
+	return bits | extra, nil
+}
+
+// parseValue 解析一个非负整数并检查其是否在字段的取值范围内
+func parseValue(s string, b bounds) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", b.name, s)
+	}
+	if uint(n) < b.min || uint(n) > b.max {
+		return 0, fmt.Errorf("%s: value %d out of range [%d, %d]", b.name, n, b.min, b.max)
+	}
+	return uint(n), nil
+}
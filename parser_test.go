@@ -0,0 +1,179 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseNext verifies Next for a few parsed specs
+func TestParseNext(t *testing.T) {
+	tests := []struct {
+		spec, from, next string
+	}{
+		{"*/5 * * * *", "2024-01-01T10:02:30Z", "2024-01-01T10:05:00Z"},
+		{"0 9 * * 1-5", "2024-01-05T09:00:00Z", "2024-01-08T09:00:00Z"},
+		{"30 23 31 12 *", "2024-12-31T23:30:00Z", "2025-12-31T23:30:00Z"},
+		{"0 0 1,15 * *", "2024-02-15T00:00:00Z", "2024-03-01T00:00:00Z"},
+	}
+	for _, tc := range tests {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tc.spec, err)
+		}
+		from, _ := time.Parse(time.RFC3339, tc.from)
+		want, _ := time.Parse(time.RFC3339, tc.next)
+		if got := s.Next(from); !got.Equal(want) {
+			t.Errorf("%q from %v: expected %v, got %v", tc.spec, from, want, got)
+		}
+	}
+}
+
+// TestParseErrors verifies that malformed specs are rejected
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "61 * * * *", "* 24 * * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("expected error for spec %q", spec)
+		}
+	}
+}
+
+// TestAddCronSpec verifies that the original spec is kept on entries added with AddCron
+func TestAddCronSpec(t *testing.T) {
+	c := New()
+	const spec = "*/15 9-17 * * 1-5"
+	id, err := c.AddCron(spec, func() {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other := c.AddFunc(Every(time.Hour), func() {})
+
+	for _, e := range c.Entries() {
+		switch e.ID {
+		case id:
+			if e.Spec != spec {
+				t.Errorf("expected spec %q, got %q", spec, e.Spec)
+			}
+		case other:
+			if e.Spec != "" {
+				t.Errorf("expected empty spec for non-cron entry, got %q", e.Spec)
+			}
+		}
+	}
+}
+
+// TestParseRollover verifies that Next rolls over month, year and leap-day boundaries
+func TestParseRollover(t *testing.T) {
+	tests := []struct {
+		spec, from, next string
+	}{
+		{"0 0 1 * *", "2024-01-31T12:00:00Z", "2024-02-01T00:00:00Z"},
+		{"0 0 31 * *", "2024-04-15T00:00:00Z", "2024-05-31T00:00:00Z"},
+		{"0 0 29 2 *", "2024-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+		{"59 23 * * *", "2024-12-31T23:59:00Z", "2025-01-01T23:59:00Z"},
+		{"0 12 * 1 0", "2024-12-01T00:00:00Z", "2025-01-05T12:00:00Z"},
+	}
+	for _, tc := range tests {
+		s, err := Parse(tc.spec)
+		if err != nil {
+			t.Fatalf("Parse(%q) returned error: %v", tc.spec, err)
+		}
+		from, _ := time.Parse(time.RFC3339, tc.from)
+		want, _ := time.Parse(time.RFC3339, tc.next)
+		if got := s.Next(from); !got.Equal(want) {
+			t.Errorf("%q from %v: expected %v, got %v", tc.spec, from, want, got)
+		}
+	}
+}
+
+// TestParseLocation verifies that fields are matched in the scheduler's location
+func TestParseLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk), WithLocation(loc))
+	id, err := c.AddCron("0 9 * * *", func() {})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.Start()
+	clk.BlockUntil(1)
+	c.Stop()
+
+	e, _ := c.Entry(id)
+	if want := time.Date(2024, 1, 1, 9, 0, 0, 0, loc); !e.Next.Equal(want) {
+		t.Errorf("expected next %v, got %v", want, e.Next)
+	}
+	if e.Next.Location() != loc {
+		t.Errorf("expected next in %v, got %v", loc, e.Next.Location())
+	}
+}
+
+// TestParseErrorMessage verifies that errors name the offending field
+func TestParseErrorMessage(t *testing.T) {
+	_, err := Parse("61 * * * *")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "61") {
+		t.Errorf("expected error to mention the bad value, got %q", err)
+	}
+}
+
+// TestParseWithSeconds verifies six-field specs at sub-minute resolution
+func TestParseWithSeconds(t *testing.T) {
+	tests := []struct {
+		spec, from, next string
+	}{
+		{"*/30 * * * * *", "2024-01-01T10:00:00Z", "2024-01-01T10:00:30Z"},
+		{"*/30 * * * * *", "2024-01-01T10:00:30Z", "2024-01-01T10:01:00Z"},
+		{"15 0 12 * * *", "2024-01-01T12:00:15Z", "2024-01-02T12:00:15Z"},
+		{"0 0 0 1 1 *", "2024-12-31T23:59:59Z", "2025-01-01T00:00:00Z"},
+	}
+	for _, tc := range tests {
+		s, err := ParseWithSeconds(tc.spec)
+		if err != nil {
+			t.Fatalf("ParseWithSeconds(%q) returned error: %v", tc.spec, err)
+		}
+		from, _ := time.Parse(time.RFC3339, tc.from)
+		want, _ := time.Parse(time.RFC3339, tc.next)
+		if got := s.Next(from); !got.Equal(want) {
+			t.Errorf("%q from %v: expected %v, got %v", tc.spec, from, want, got)
+		}
+	}
+
+	for _, spec := range []string{"* * * * *", "60 * * * * *"} {
+		if _, err := ParseWithSeconds(spec); err == nil {
+			t.Errorf("expected error for spec %q", spec)
+		}
+	}
+}
+
+// TestParseWithSecondsLateWake verifies that a late wake does not shift later fires
+func TestParseWithSecondsLateWake(t *testing.T) {
+	s, _ := ParseWithSeconds("*/30 * * * * *")
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	for i := 1; i <= 4; i++ {
+		next := s.Next(now)
+		if want := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).Add(time.Duration(i) * 30 * time.Second); !next.Equal(want) {
+			t.Fatalf("expected fire %d at %v, got %v", i, want, next)
+		}
+		// simulate the scheduler waking slightly after the scheduled time
+		now = next.Add(250 * time.Millisecond)
+	}
+}
+
+// TestParseDefaultsToZeroSeconds verifies that five-field specs fire at second zero
+func TestParseDefaultsToZeroSeconds(t *testing.T) {
+	s, _ := Parse("* * * * *")
+	from := time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC)
+	if got, want := s.Next(from), time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	manual := &SpecSchedule{Minute: 1 << 5, Hour: 1<<24 - 1, Dom: 1<<32 - 2 | starBit, Month: 1<<13 - 2 | starBit, Dow: 1<<7 - 1 | starBit}
+	if got, want := manual.Next(from), time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected a zero Second field to mean second 0, got %v", got)
+	}
+}
@@ -0,0 +1,35 @@
+package cron
+
+import "math/rand/v2"
+
+// Rand 定义了随机调度使用的随机数源接口
+// *rand.Rand(math/rand/v2)满足此接口，测试中可注入固定种子的随机源以获得确定的结果
+// 注意: *rand.Rand不是并发安全的，不要在多个调度器之间共享
+type Rand interface {
+	Int64N(n int64) int64
+	Float64() float64
+}
+
+// globalRand 使用math/rand/v2的全局随机源，并发安全
+type globalRand struct{}
+
+// Int64N 实现Rand接口
+func (globalRand) Int64N(n int64) int64 { return rand.Int64N(n) }
+
+// Float64 实现Rand接口
+func (globalRand) Float64() float64 { return rand.Float64() }
+
+// randFloat 从调度器的随机数源取一个[0, 1)的随机数
+func (c *Cron) randFloat() float64 {
+	c.randMu.Lock()
+	defer c.randMu.Unlock()
+	return randOrDefault(c.rand).Float64()
+}
+
+// randOrDefault 返回r，r为nil时返回全局随机源
+func randOrDefault(r Rand) Rand {
+	if r == nil {
+		return globalRand{}
+	}
+	return r
+}
@@ -0,0 +1,49 @@
+package cron
+
+import "time"
+
+// fireRingSize 每个任务保留的最近触发时间的数量
+const fireRingSize = 64
+
+// fireRing 是保存最近触发时间的环形缓冲区
+type fireRing struct {
+	times [fireRingSize]time.Time
+	next  int // 下一次写入的位置
+	n     int // 已保存的数量
+}
+
+// recordFire 记录任务在now时刻触发了一次，调用者需要持有entriesMu
+func (c *Cron) recordFire(e *Entry, now time.Time) {
+	if e.fires == nil {
+		e.fires = &fireRing{}
+	}
+	r := e.fires
+	r.times[r.next] = now
+	r.next = (r.next + 1) % fireRingSize
+	if r.n < fireRingSize {
+		r.n++
+	}
+}
+
+// FireRate 返回任务在最近window时间内每秒的触发次数，任务不存在或window不为正时返回0
+// 用于发现触发过于频繁的调度配置，只统计主循环按调度触发的次数
+// 每个任务只保留最近64次触发，window内的触发超过64次时结果会偏低
+func (c *Cron) FireRate(id EntryID, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	e := c.entry(id)
+	if e == nil || e.fires == nil {
+		return 0
+	}
+	since := c.now().Add(-window)
+	count := 0
+	for i := 0; i < e.fires.n; i++ {
+		if e.fires.times[i].After(since) {
+			count++
+		}
+	}
+	return float64(count) / window.Seconds()
+}
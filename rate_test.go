@@ -0,0 +1,35 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFireRate verifies that the rate reflects the number of fires within the window
+func TestFireRate(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	id := c.AddFunc(Every(time.Second), func() {})
+	idle := c.AddFunc(&TestSchedule{}, func() {})
+	c.Start()
+
+	for range 10 {
+		clk.BlockUntil(1)
+		clk.Advance(time.Second)
+	}
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	if got := c.FireRate(id, 10*time.Second); got < 0.99 || got > 1.01 {
+		t.Errorf("expected about 1 fire per second, got %v", got)
+	}
+	if got := c.FireRate(id, 20*time.Second); got < 0.49 || got > 0.51 {
+		t.Errorf("expected about 0.5 fires per second over a wider window, got %v", got)
+	}
+	if got := c.FireRate(idle, 10*time.Second); got != 0 {
+		t.Errorf("expected no fires for idle entry, got %v", got)
+	}
+	if got := c.FireRate(999, time.Second); got != 0 {
+		t.Errorf("expected 0 for unknown entry, got %v", got)
+	}
+}
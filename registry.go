@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// JobRegistry 将任务名称映射到创建Job的工厂函数，用于从YAML、JSON等配置加载任务
+// 通过WithJobRegistry交给调度器，由LoadFromConfig按EntryConfig.Job创建任务
+// 可并发使用
+type JobRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]func() Job
+}
+
+// NewJobRegistry 创建一个空的任务注册表
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{factories: make(map[string]func() Job)}
+}
+
+// Register 注册名称为name的任务工厂，每次按该名称加载任务时调用factory创建新的Job
+// 名称为空、factory为nil或名称已经注册时panic，这类错误应在程序启动时暴露
+func (r *JobRegistry) Register(name string, factory func() Job) {
+	if name == "" {
+		panic("cron: job name cannot be empty")
+	}
+	if factory == nil {
+		panic("cron: job factory cannot be nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.factories[name]; ok {
+		panic(fmt.Sprintf("cron: job %q already registered", name))
+	}
+	r.factories[name] = factory
+}
+
+// New 使用名称对应的工厂创建Job，名称未注册时返回包装ErrUnknownJob的错误
+func (r *JobRegistry) New(name string) (Job, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownJob, name)
+	}
+	return factory(), nil
+}
+
+// LoadFromConfig 按配置添加任务，任务由WithJobRegistry设置的注册表按EntryConfig.Job创建
+// 添加前先检查全部配置，任一项的任务名称未注册或调度描述无效时不添加任何任务，
+// 返回的错误通过errors.Join合并了所有问题，每个错误都包含配置的序号，未注册的名称包装ErrUnknownJob
+// 有名称的配置按AddNamedFunc的规则添加，添加失败时删除本次已经添加的任务并返回错误
+func (c *Cron) LoadFromConfig(configs []EntryConfig) error {
+	if c.registry == nil {
+		return errors.New("job registry not configured")
+	}
+	entries := make([]*Entry, 0, len(configs))
+	var errs []error
+	for i, cfg := range configs {
+		s, err := cfg.Schedule.Schedule()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config %d: %w", i, err))
+			continue
+		}
+		job, err := c.registry.New(cfg.Job)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("config %d: %w", i, err))
+			continue
+		}
+		entries = append(entries, &Entry{Name: cfg.Name, Spec: cfg.Schedule.Cron, Schedule: s, Job: job})
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	added := make([]EntryID, 0, len(entries))
+	for i, e := range entries {
+		var id EntryID
+		var err error
+		if e.Name != "" {
+			id, err = c.addNamed(e)
+		} else {
+			id, err = c.addEntry(e)
+		}
+		if err != nil {
+			for _, id := range added {
+				c.Remove(id)
+			}
+			return fmt.Errorf("config %d: %w", i, err)
+		}
+		added = append(added, id)
+	}
+	return nil
+}
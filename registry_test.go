@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRegistry registers two job factories that report which job ran
+func newTestRegistry(ran chan<- string) *JobRegistry {
+	r := NewJobRegistry()
+	r.Register("backup", func() Job { return FuncJob(func() { ran <- "backup" }) })
+	r.Register("cleanup", func() Job { return FuncJob(func() { ran <- "cleanup" }) })
+	return r
+}
+
+// TestLoadFromConfig verifies that entries are built from registered factories
+func TestLoadFromConfig(t *testing.T) {
+	ran := make(chan string, 2)
+	c := New(WithJobRegistry(newTestRegistry(ran)))
+	err := c.LoadFromConfig([]EntryConfig{
+		{Name: "nightly-backup", Job: "backup", Schedule: ScheduleSpec{Cron: "0 2 * * *"}},
+		{Job: "cleanup", Schedule: ScheduleSpec{Every: time.Hour}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+
+	entries := c.EntriesInOrder()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Name != "nightly-backup" || entries[1].Name != "" {
+		t.Errorf("unexpected entry names %q and %q", entries[0].Name, entries[1].Name)
+	}
+	if entries[0].Spec != "0 2 * * *" {
+		t.Errorf("expected cron spec to be kept, got %q", entries[0].Spec)
+	}
+	for i, want := range []string{"backup", "cleanup"} {
+		c.RunNow(entries[i].ID)
+		if got := <-ran; got != want {
+			t.Errorf("expected entry %d to run %q, got %q", entries[i].ID, want, got)
+		}
+	}
+}
+
+// TestLoadFromConfigErrors verifies that invalid configs add nothing and report every problem
+func TestLoadFromConfigErrors(t *testing.T) {
+	c := New(WithJobRegistry(newTestRegistry(make(chan string, 1))))
+	err := c.LoadFromConfig([]EntryConfig{
+		{Job: "backup", Schedule: ScheduleSpec{Every: time.Hour}},
+		{Job: "deploy", Schedule: ScheduleSpec{Every: time.Hour}},
+		{Job: "cleanup"},
+	})
+	if !errors.Is(err, ErrUnknownJob) {
+		t.Errorf("expected ErrUnknownJob, got %v", err)
+	}
+	for _, want := range []string{`config 1: unknown job: "deploy"`, "config 2:"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to mention %q, got %v", want, err)
+		}
+	}
+	if n := len(c.Entries()); n != 0 {
+		t.Errorf("expected no entries after invalid config, got %d", n)
+	}
+
+	// a duplicate name fails while adding and rolls back the entries already added
+	c.AddNamedFunc("taken", Every(time.Hour), func() {})
+	err = c.LoadFromConfig([]EntryConfig{
+		{Name: "fresh", Job: "backup", Schedule: ScheduleSpec{Every: time.Hour}},
+		{Name: "taken", Job: "cleanup", Schedule: ScheduleSpec{Every: time.Hour}},
+	})
+	if !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("expected ErrDuplicateName, got %v", err)
+	}
+	if _, ok := c.EntryByName("fresh"); ok {
+		t.Error("expected entries added before the failure to be removed")
+	}
+
+	if err := New().LoadFromConfig(nil); err == nil {
+		t.Error("expected error without a job registry")
+	}
+}
+
+// TestJobRegistryRegisterPanics verifies that invalid registrations panic
+func TestJobRegistryRegisterPanics(t *testing.T) {
+	r := newTestRegistry(nil)
+	for name, register := range map[string]func(){
+		"empty name":  func() { r.Register("", func() Job { return nil }) },
+		"nil factory": func() { r.Register("restore", nil) },
+		"duplicate":   func() { r.Register("backup", func() Job { return nil }) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected Register to panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+	if err := WithJobRegistry(nil)(&Cron{}); err == nil {
+		t.Error("expected error for nil job registry")
+	}
+}
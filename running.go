@@ -0,0 +1,30 @@
+package cron
+
+// jobStarted 记录任务的一个实例开始执行
+func (c *Cron) jobStarted(id EntryID) {
+	c.activeMu.Lock()
+	defer c.activeMu.Unlock()
+	if c.activeRuns == nil {
+		c.activeRuns = make(map[EntryID]int)
+	}
+	c.activeRuns[id]++
+}
+
+// jobFinished 记录任务的一个实例执行结束
+func (c *Cron) jobFinished(id EntryID) {
+	c.activeMu.Lock()
+	defer c.activeMu.Unlock()
+	if c.activeRuns[id] <= 1 {
+		delete(c.activeRuns, id)
+		return
+	}
+	c.activeRuns[id]--
+}
+
+// IsJobRunning 返回指定任务是否有实例正在执行
+// 包括调度触发、RunNow和依赖触发启动的执行，任务被删除后仍在执行的实例同样计入
+func (c *Cron) IsJobRunning(id EntryID) bool {
+	c.activeMu.Lock()
+	defer c.activeMu.Unlock()
+	return c.activeRuns[id] > 0
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIsJobRunning verifies that a slow job is reported as running only while it executes
+func TestIsJobRunning(t *testing.T) {
+	c := New()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	id := c.AddFunc(&TestSchedule{}, func() {
+		close(started)
+		<-release
+	})
+	if c.IsJobRunning(id) {
+		t.Error("expected job not to be running before it is triggered")
+	}
+
+	c.RunNow(id)
+	<-started
+	if !c.IsJobRunning(id) {
+		t.Error("expected job to be running")
+	}
+
+	close(release)
+	<-c.Stop().Done()
+	if c.IsJobRunning(id) {
+		t.Error("expected job not to be running after it finished")
+	}
+	if c.IsJobRunning(id + 1) {
+		t.Error("expected unknown entry not to be running")
+	}
+}
+
+// TestIsJobRunningConcurrent verifies that overlapping runs are counted until the last one finishes
+func TestIsJobRunningConcurrent(t *testing.T) {
+	c := New()
+	id := c.AddFunc(&TestSchedule{}, func() { time.Sleep(20 * time.Millisecond) })
+	c.RunNow(id)
+	time.Sleep(10 * time.Millisecond)
+	c.RunNow(id)
+	time.Sleep(15 * time.Millisecond)
+	if !c.IsJobRunning(id) {
+		t.Error("expected the second run to keep the job running")
+	}
+	<-c.Stop().Done()
+	if c.IsJobRunning(id) {
+		t.Error("expected job not to be running after both runs finished")
+	}
+}
@@ -1,6 +1,9 @@
 package cron
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 // DelaySchedule 是一个简单的延迟调度器
 // 基于固定的时间间隔进行调度
@@ -22,3 +25,325 @@ func Every(delay time.Duration) DelaySchedule {
 		Delay: delay,
 	}
 }
+
+// midnightSchedule 以所在时区的零点为锚点，按固定间隔在每天的本地时间点触发
+type midnightSchedule struct {
+	interval time.Duration
+}
+
+// EveryFromMidnight 创建一个从每天本地零点开始、按interval间隔触发的调度器
+// 例如: EveryFromMidnight(6*time.Hour)在本地时间00:00、06:00、12:00、18:00触发
+// 时区取自传入Next的时间，即调度器配置的时区
+// 每天重新以零点为锚点并按本地时钟计算，因此夏令时切换导致一天只有23或25小时时，
+// 触发时间仍然对齐到本地时钟；若interval不能整除24小时，最后一段不足interval的时间会被跳过
+// interval必须为正数，否则任务不会被触发
+func EveryFromMidnight(interval time.Duration) Schedule {
+	return midnightSchedule{interval: interval}
+}
+
+// Next 计算下一次执行时间
+// 返回严格晚于t的第一个对齐时间点
+func (s midnightSchedule) Next(t time.Time) time.Time {
+	if s.interval <= 0 {
+		return time.Time{}
+	}
+	year, month, day := t.Date()
+	for {
+		for offset := time.Duration(0); offset < 24*time.Hour; offset += s.interval {
+			// 通过time.Date规范化纳秒偏移，按本地时钟而不是绝对时长推进
+			next := time.Date(year, month, day, 0, 0, 0, int(offset), t.Location())
+			if next.After(t) {
+				return next
+			}
+		}
+		day++
+	}
+}
+
+// atSchedule 只在固定时刻触发一次
+type atSchedule struct {
+	at time.Time
+}
+
+// At 创建一个只在t时刻触发一次的调度器
+// 触发后下次执行时间为零值，任务保留在任务列表中但不会再被触发
+// 添加时t已经过去的任务永远不会被触发
+func At(t time.Time) Schedule {
+	return atSchedule{at: t}
+}
+
+// Next 计算下一次执行时间
+// t早于触发时刻时返回触发时刻，否则返回零值
+func (s atSchedule) Next(t time.Time) time.Time {
+	if s.at.After(t) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+// afterSchedule 在首次计算下次执行时间的d之后触发一次
+type afterSchedule struct {
+	delay time.Duration
+	mu    sync.Mutex
+	at    time.Time // 首次调用Next时确定的触发时刻
+}
+
+// After 创建一个在首次计算下次执行时间的delay之后触发一次的调度器
+// 调度器运行时添加的任务从添加时开始计时，启动前添加的任务从Start开始计时
+// 触发时刻只在首次调用Next时确定，之后的唤醒不会重新计时
+// 每个任务应使用单独的After调度器实例
+func After(delay time.Duration) Schedule {
+	return &afterSchedule{delay: delay}
+}
+
+// Next 计算下一次执行时间
+// 首次调用时以t加上延迟作为触发时刻，t早于触发时刻时返回触发时刻，否则返回零值
+func (s *afterSchedule) Next(t time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.at.IsZero() {
+		s.at = t.Add(s.delay)
+	}
+	if s.at.After(t) {
+		return s.at
+	}
+	return time.Time{}
+}
+
+// WeightedDelay 是加权调度器中的一个候选间隔
+type WeightedDelay struct {
+	Delay  time.Duration // 执行间隔
+	Weight int           // 被选中的权重，小于等于0的候选不会被选中
+}
+
+// WeightedDelaySchedule 是一个按权重随机选择间隔的调度器
+// 每次计算下一次执行时间时，按权重从Delays中随机选择一个间隔
+type WeightedDelaySchedule struct {
+	Delays []WeightedDelay // 候选间隔
+	Rand   Rand            // 随机数源，为nil时使用全局随机源
+}
+
+// WeightedSchedule 创建一个按权重随机选择间隔的调度器
+// 例如: 大部分时候间隔1秒，偶尔间隔5秒
+//
+//	cron.WeightedSchedule([]cron.WeightedDelay{
+//		{Delay: time.Second, Weight: 9},
+//		{Delay: 5 * time.Second, Weight: 1},
+//	})
+func WeightedSchedule(delays []WeightedDelay) *WeightedDelaySchedule {
+	return &WeightedDelaySchedule{
+		Delays: delays,
+	}
+}
+
+// Next 计算下一次执行时间
+// 参数t是当前时间，返回t加上按权重随机选出的间隔
+// 没有权重为正的候选时返回零值，任务不会再被触发
+func (s *WeightedDelaySchedule) Next(t time.Time) time.Time {
+	var total int64
+	for _, d := range s.Delays {
+		if d.Weight > 0 {
+			total += int64(d.Weight)
+		}
+	}
+	if total == 0 {
+		return time.Time{}
+	}
+
+	n := randOrDefault(s.Rand).Int64N(total)
+	for _, d := range s.Delays {
+		if d.Weight <= 0 {
+			continue
+		}
+		if n < int64(d.Weight) {
+			return t.Add(d.Delay)
+		}
+		n -= int64(d.Weight)
+	}
+	return time.Time{}
+}
+
+// RandomWithinSchedule 是在每个周期内随机选择一个时刻触发的调度器
+// 周期按调度器时区的本地时钟对齐，例如period为1小时时周期为本地整点到下一个整点
+// 不超过24小时的周期与EveryFromMidnight相同，以每天的本地零点为锚点，不能整除24小时时每天最后一个周期较短；
+// 更长的周期按本地时钟截断对齐
+type RandomWithinSchedule struct {
+	Period time.Duration // 周期长度
+	Rand   Rand          // 随机数源，为nil时使用全局随机源
+}
+
+// RandomWithin 创建一个每个周期内随机触发一次的调度器
+// 例如: RandomWithin(time.Hour)每小时在随机的某个时刻触发一次
+// 与抖动不同，周期内没有固定的基准时间
+func RandomWithin(period time.Duration) *RandomWithinSchedule {
+	return &RandomWithinSchedule{
+		Period: period,
+	}
+}
+
+// Next 计算下一次执行时间
+// 参数t是当前时间，返回t所在周期的下一个周期[start, start+Period)内的随机时刻
+// 总是选择下一个周期，因此每个周期最多触发一次且执行时间严格递增
+// Period不为正时返回零值，任务不会再被触发
+func (s *RandomWithinSchedule) Next(t time.Time) time.Time {
+	if s.Period <= 0 {
+		return time.Time{}
+	}
+	start, span := s.windowStart(t).Add(s.Period), s.Period
+	if s.Period <= 24*time.Hour {
+		m := midnightSchedule{interval: s.Period}
+		start = m.Next(t)
+		// 随机时刻不超出下一个周期的开始，每天最后一个较短的周期同样最多触发一次
+		span = min(span, m.Next(start).Sub(start))
+	}
+	return start.Add(time.Duration(randOrDefault(s.Rand).Int64N(int64(span))))
+}
+
+// windowStart 返回t所在周期的开始时刻
+func (s *RandomWithinSchedule) windowStart(t time.Time) time.Time {
+	if s.Period > 24*time.Hour {
+		_, offset := t.Zone()
+		shift := time.Duration(offset) * time.Second
+		return t.Add(shift).Truncate(s.Period).Add(-shift)
+	}
+	year, month, day := t.Date()
+	start := time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	for offset := s.Period; offset < 24*time.Hour; offset += s.Period {
+		// 与midnightSchedule相同，通过time.Date按本地时钟推进
+		next := time.Date(year, month, day, 0, 0, 0, int(offset), t.Location())
+		if next.After(t) {
+			break
+		}
+		start = next
+	}
+	return start
+}
+
+// TimeOfDay 表示一天中的某个时刻
+type TimeOfDay struct {
+	Hour   int // 小时，0-23
+	Minute int // 分钟，0-59
+}
+
+// on 返回指定日期在loc时区中的该时刻
+func (d TimeOfDay) on(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return time.Date(year, month, day, d.Hour, d.Minute, 0, 0, loc)
+}
+
+// BusinessDaySchedule 是按工作日计数的调度器
+// 每隔Interval个工作日在Time时刻执行一次，周六、周日和Holidays中的日期不计入工作日
+// 例如: Interval为2时，周一执行后下一次在周三执行，周三是节假日时顺延到周四
+type BusinessDaySchedule struct {
+	Interval int         // 间隔的工作日数，小于1时视为1
+	Time     TimeOfDay   // 执行时刻，按调度器时区计算
+	Holidays []time.Time // 节假日，只比较年月日
+}
+
+// Next 计算下一次执行时间
+// 如果t所在日期是工作日且当天的执行时刻还没到，返回当天的执行时刻；
+// 如果t所在日期不是工作日，返回下一个工作日的执行时刻；
+// 否则从t所在日期起向后数Interval个工作日
+func (s BusinessDaySchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	year, month, day := t.Date()
+	if s.isBusinessDay(year, month, day, loc) && s.Time.on(year, month, day, loc).After(t) {
+		return s.Time.on(year, month, day, loc)
+	}
+
+	remaining := s.Interval
+	if remaining < 1 || !s.isBusinessDay(year, month, day, loc) {
+		remaining = 1
+	}
+	for remaining > 0 {
+		day++
+		if s.isBusinessDay(year, month, day, loc) {
+			remaining--
+		}
+	}
+	return s.Time.on(year, month, day, loc)
+}
+
+// isBusinessDay 判断指定日期是否为工作日
+func (s BusinessDaySchedule) isBusinessDay(year int, month time.Month, day int, loc *time.Location) bool {
+	date := time.Date(year, month, day, 12, 0, 0, 0, loc)
+	if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	year, month, day = date.Date()
+	for _, h := range s.Holidays {
+		hy, hm, hd := h.Date()
+		if hy == year && hm == month && hd == day {
+			return false
+		}
+	}
+	return true
+}
+
+// FixedDelaySchedule 是从上一次执行完成时开始计算间隔的调度器
+// 与DelaySchedule从触发时刻开始计算不同，任务执行期间不会计时，
+// 例如执行耗时100ms、延迟100ms的任务每200ms触发一次
+// 任务执行期间Entries返回的Next为零值，执行完成后才会确定下次执行时间
+type FixedDelaySchedule struct {
+	Delay time.Duration // 上一次执行完成到下一次触发的间隔
+}
+
+// Next 计算下一次执行时间
+// 参数t是当前时间，返回t加上延迟时间后的时间
+// 调度器在任务执行完成后以完成时间调用Next
+func (s FixedDelaySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.Delay)
+}
+
+// FixedDelay 创建一个从上一次执行完成时开始计算间隔的调度器
+// 例如: FixedDelay(time.Minute)在每次执行完成一分钟后再次触发
+func FixedDelay(delay time.Duration) FixedDelaySchedule {
+	return FixedDelaySchedule{
+		Delay: delay,
+	}
+}
+
+// Phase 是分阶段调度中的一个阶段
+type Phase struct {
+	Schedule Schedule // 本阶段使用的调度器
+	MaxRuns  int      // 本阶段触发的次数，小于等于0表示不限制，之后的阶段不会生效
+}
+
+// PhasedSchedule 是按阶段切换调度器的调度器
+// 每个阶段触发MaxRuns次后切换到下一个阶段，所有阶段用完后任务不再被触发
+// 阶段由调度器按任务已触发的次数选择，同一个PhasedSchedule可以被多个任务共用
+type PhasedSchedule struct {
+	Phases []Phase
+}
+
+// SchedulePhases 创建一个分阶段的调度器
+// 例如: 启动时每10秒执行5次，之后每小时执行一次
+//
+//	cron.SchedulePhases([]cron.Phase{
+//		{Schedule: cron.Every(10 * time.Second), MaxRuns: 5},
+//		{Schedule: cron.Every(time.Hour)},
+//	})
+func SchedulePhases(phases []Phase) *PhasedSchedule {
+	return &PhasedSchedule{
+		Phases: phases,
+	}
+}
+
+// Next 使用第一个阶段计算下一次执行时间
+// 调度器运行时会按任务已触发的次数选择阶段，Next只用于不了解触发次数的场景
+func (s *PhasedSchedule) Next(t time.Time) time.Time {
+	if p := s.phase(0); p != nil {
+		return p.Next(t)
+	}
+	return time.Time{}
+}
+
+// phase 返回已触发runs次后应使用的调度器，所有阶段都已用完时返回nil
+func (s *PhasedSchedule) phase(runs int) Schedule {
+	for _, p := range s.Phases {
+		if p.MaxRuns <= 0 || runs < p.MaxRuns {
+			return p.Schedule
+		}
+		runs -= p.MaxRuns
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package cron
 
 import (
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -48,3 +51,342 @@ func (s *DailySchedule) Next(t time.Time) time.Time {
 	}
 	return next
 }
+
+// TestEveryFromMidnightDST verifies that fires stay aligned to local clock times across spring-forward
+func TestEveryFromMidnightDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	s := EveryFromMidnight(6 * time.Hour)
+
+	// 2024-03-10 is the spring-forward day in New York (23 hours long)
+	now := time.Date(2024, 3, 9, 20, 0, 0, 0, loc)
+	expected := []time.Time{
+		time.Date(2024, 3, 10, 0, 0, 0, 0, loc),
+		time.Date(2024, 3, 10, 6, 0, 0, 0, loc),
+		time.Date(2024, 3, 10, 12, 0, 0, 0, loc),
+		time.Date(2024, 3, 10, 18, 0, 0, 0, loc),
+		time.Date(2024, 3, 11, 0, 0, 0, 0, loc),
+		time.Date(2024, 3, 11, 6, 0, 0, 0, loc),
+	}
+	for i, want := range expected {
+		now = s.Next(now)
+		if !now.Equal(want) {
+			t.Fatalf("fire %d: expected %v, got %v", i, want, now)
+		}
+	}
+
+	// across spring-forward only 5 real hours separate local midnight and 06:00
+	gap := expected[1].Sub(expected[0])
+	if gap != 5*time.Hour {
+		t.Errorf("expected 5h between 00:00 and 06:00 on spring-forward day, got %v", gap)
+	}
+}
+
+// TestEveryFromMidnightUneven verifies that an interval not dividing a day re-anchors at midnight
+func TestEveryFromMidnightUneven(t *testing.T) {
+	s := EveryFromMidnight(7 * time.Hour)
+	now := time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC)
+	next := s.Next(now)
+	if want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("expected %v, got %v", want, next)
+	}
+
+	if next := EveryFromMidnight(0).Next(now); !next.IsZero() {
+		t.Errorf("expected zero time for non-positive interval, got %v", next)
+	}
+}
+
+// TestWeightedScheduleSequence verifies that a seeded weighted schedule produces a fixed sequence of delays
+func TestWeightedScheduleSequence(t *testing.T) {
+	s := WeightedSchedule([]WeightedDelay{
+		{Delay: time.Second, Weight: 3},
+		{Delay: 5 * time.Second, Weight: 1},
+	})
+	s.Rand = rand.New(rand.NewPCG(7, 11))
+
+	expected := []time.Duration{1, 5, 1, 1, 5, 5, 1, 5, 5, 1, 1, 1}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, want := range expected {
+		next := s.Next(now)
+		if got := next.Sub(now); got != want*time.Second {
+			t.Fatalf("tick %d: expected delay %v, got %v", i, want*time.Second, got)
+		}
+		now = next
+	}
+}
+
+// TestWeightedScheduleWeights verifies that non-positive weights are never selected
+func TestWeightedScheduleWeights(t *testing.T) {
+	s := WeightedSchedule([]WeightedDelay{
+		{Delay: time.Second, Weight: 0},
+		{Delay: 2 * time.Second, Weight: 1},
+		{Delay: 3 * time.Second, Weight: -1},
+	})
+	s.Rand = rand.New(rand.NewPCG(1, 2))
+
+	now := time.Now()
+	for i := 0; i < 100; i++ {
+		if got := s.Next(now).Sub(now); got != 2*time.Second {
+			t.Fatalf("expected only the positive-weight delay, got %v", got)
+		}
+	}
+
+	if next := WeightedSchedule(nil).Next(now); !next.IsZero() {
+		t.Errorf("expected zero time without candidates, got %v", next)
+	}
+}
+
+// TestBusinessDaySchedule verifies business-day spacing across a week containing a holiday
+func TestBusinessDaySchedule(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	s := BusinessDaySchedule{
+		Interval: 2,
+		Time:     TimeOfDay{Hour: 9},
+		Holidays: []time.Time{
+			time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	now := time.Date(2024, 12, 23, 8, 0, 0, 0, loc) // Monday before Christmas
+	expected := []time.Time{
+		time.Date(2024, 12, 23, 9, 0, 0, 0, loc), // same day, time not yet reached
+		time.Date(2024, 12, 26, 9, 0, 0, 0, loc), // Tue counts, Wed is a holiday, Thu
+		time.Date(2024, 12, 30, 9, 0, 0, 0, loc), // Fri counts, weekend skipped, Mon
+		time.Date(2025, 1, 2, 9, 0, 0, 0, loc),   // Tue counts, New Year skipped, Thu
+	}
+	for i, want := range expected {
+		now = s.Next(now)
+		if !now.Equal(want) {
+			t.Fatalf("fire %d: expected %v, got %v", i, want, now)
+		}
+	}
+}
+
+// TestBusinessDayScheduleFromWeekend verifies that a weekend start fires on the next business day
+func TestBusinessDayScheduleFromWeekend(t *testing.T) {
+	s := BusinessDaySchedule{Interval: 3, Time: TimeOfDay{Hour: 17, Minute: 30}}
+	saturday := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	if got, want := s.Next(saturday), time.Date(2024, 6, 3, 17, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+// TestFixedDelay verifies that the delay is measured from the end of the previous run
+func TestFixedDelay(t *testing.T) {
+	c := New()
+	var mu sync.Mutex
+	var starts []time.Time
+	c.AddFunc(FixedDelay(100*time.Millisecond), func() {
+		mu.Lock()
+		starts = append(starts, time.Now())
+		mu.Unlock()
+		time.Sleep(100 * time.Millisecond)
+	})
+	c.Start()
+	time.Sleep(550 * time.Millisecond)
+	<-c.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(starts) < 2 {
+		t.Fatalf("expected at least 2 runs, got %d", len(starts))
+	}
+	for i := 1; i < len(starts); i++ {
+		if gap := starts[i].Sub(starts[i-1]); gap < 190*time.Millisecond || gap > 260*time.Millisecond {
+			t.Errorf("expected runs about 200ms apart, got %v", gap)
+		}
+	}
+}
+
+// TestRandomWithin verifies that each fire lands inside its own period window
+func TestRandomWithin(t *testing.T) {
+	s := RandomWithin(time.Hour)
+	s.Rand = rand.New(rand.NewPCG(1, 2))
+	now := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+
+	window := now.Truncate(time.Hour)
+	minutes := map[int]bool{}
+	for range 24 {
+		next := s.Next(now)
+		window = window.Add(time.Hour)
+		if next.Before(window) || !next.Before(window.Add(time.Hour)) {
+			t.Fatalf("expected fire in [%v, %v), got %v", window, window.Add(time.Hour), next)
+		}
+		if !next.After(now) {
+			t.Fatalf("expected next %v after %v", next, now)
+		}
+		minutes[next.Minute()] = true
+		now = next
+	}
+	if len(minutes) < 10 {
+		t.Errorf("expected fires at varied minutes, got %d distinct", len(minutes))
+	}
+	if next := RandomWithin(0).Next(now); !next.IsZero() {
+		t.Errorf("expected zero next for non-positive period, got %v", next)
+	}
+}
+
+// TestRandomWithinLocal verifies that periods align to the local clock of a non-UTC location
+func TestRandomWithinLocal(t *testing.T) {
+	for _, tc := range []struct {
+		loc    *time.Location
+		period time.Duration
+		now    time.Time
+		window time.Time
+	}{
+		{
+			loc:    time.FixedZone("UTC+5:30", 5*3600+1800),
+			period: time.Hour,
+			now:    time.Date(2024, 1, 1, 10, 10, 0, 0, time.FixedZone("UTC+5:30", 5*3600+1800)),
+			window: time.Date(2024, 1, 1, 11, 0, 0, 0, time.FixedZone("UTC+5:30", 5*3600+1800)),
+		},
+		{
+			loc:    time.FixedZone("UTC+8", 8*3600),
+			period: 24 * time.Hour,
+			now:    time.Date(2024, 1, 1, 3, 0, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+			window: time.Date(2024, 1, 2, 0, 0, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+		},
+		{
+			loc:    time.FixedZone("UTC+8", 8*3600),
+			period: 48 * time.Hour,
+			now:    time.Date(2024, 1, 1, 3, 0, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+			window: time.Date(2024, 1, 2, 0, 0, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+		},
+	} {
+		s := RandomWithin(tc.period)
+		s.Rand = rand.New(rand.NewPCG(1, 2))
+		for range 20 {
+			next := s.Next(tc.now)
+			if next.Before(tc.window) || !next.Before(tc.window.Add(tc.period)) {
+				t.Fatalf("%v in %v: expected fire in [%v, %v), got %v", tc.period, tc.loc, tc.window, tc.window.Add(tc.period), next)
+			}
+		}
+		if start := s.windowStart(tc.window.Add(time.Minute)); !start.Equal(tc.window) {
+			t.Errorf("%v in %v: expected window start %v, got %v", tc.period, tc.loc, tc.window, start)
+		}
+	}
+
+	// a period that does not divide the day leaves a shorter last window before midnight
+	s := RandomWithin(7 * time.Hour)
+	s.Rand = rand.New(rand.NewPCG(1, 2))
+	now := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+	for range 20 {
+		if next := s.Next(now); next.Before(now.Add(6*time.Hour)) || !next.Before(now.Add(9*time.Hour)) {
+			t.Fatalf("expected fire in the 21:00-24:00 window, got %v", next)
+		}
+	}
+}
+
+// TestSchedulePhases verifies that an entry switches to the next phase after the allotted runs
+func TestSchedulePhases(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	var mu sync.Mutex
+	var fires []time.Time
+	id := c.AddFunc(SchedulePhases([]Phase{
+		{Schedule: Every(10 * time.Second), MaxRuns: 5},
+		{Schedule: Every(time.Hour)},
+	}), func() {
+		mu.Lock()
+		fires = append(fires, clk.Now())
+		mu.Unlock()
+	})
+	c.Start()
+
+	for range 6 {
+		clk.BlockUntil(1)
+		clk.Advance(10 * time.Second)
+	}
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(fires) != 5 {
+		t.Fatalf("expected 5 fast runs, got %d", len(fires))
+	}
+	e, _ := c.Entry(id)
+	if want := start.Add(50 * time.Second).Add(time.Hour); !e.Next.Equal(want) {
+		t.Errorf("expected next %v after switching phases, got %v", want, e.Next)
+	}
+}
+
+// TestSchedulePhasesExhausted verifies that bounded phases stop the entry once used up
+func TestSchedulePhasesExhausted(t *testing.T) {
+	s := SchedulePhases([]Phase{{Schedule: Every(time.Second), MaxRuns: 2}})
+	if s.phase(1) == nil {
+		t.Error("expected phase for the second run")
+	}
+	if s.phase(2) != nil {
+		t.Error("expected no phase after the bounded runs")
+	}
+}
+
+// TestAt verifies that a one-shot schedule fires exactly once and then stays inactive
+func TestAt(t *testing.T) {
+	at := time.Now().Add(100 * time.Millisecond)
+	if next := At(at).Next(at.Add(-time.Second)); !next.Equal(at) {
+		t.Errorf("expected %v before the deadline, got %v", at, next)
+	}
+	if next := At(at).Next(at); !next.IsZero() {
+		t.Errorf("expected zero time at the deadline, got %v", next)
+	}
+
+	c := New()
+	var runs atomic.Int32
+	id := c.AddFunc(At(at), func() { runs.Add(1) })
+	c.AddFunc(At(time.Now().Add(-time.Second)), func() { t.Error("expected a past At schedule never to fire") })
+	c.Start()
+	time.Sleep(300 * time.Millisecond)
+	<-c.Stop().Done()
+
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected exactly one run, got %d", n)
+	}
+	if e, _ := c.Entry(id); !e.Next.IsZero() || !e.Prev.Equal(at) {
+		t.Errorf("expected a fired entry with zero next run, got prev %v next %v", e.Prev, e.Next)
+	}
+}
+
+// TestAfter verifies that a delayed one-shot schedule is anchored at its first Next call and fires once
+func TestAfter(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := After(time.Minute)
+	if next := s.Next(base); !next.Equal(base.Add(time.Minute)) {
+		t.Errorf("expected %v, got %v", base.Add(time.Minute), next)
+	}
+	if next := s.Next(base.Add(30 * time.Second)); !next.Equal(base.Add(time.Minute)) {
+		t.Errorf("expected later wakes not to move the deadline, got %v", next)
+	}
+	if next := s.Next(base.Add(time.Minute)); !next.IsZero() {
+		t.Errorf("expected zero time once fired, got %v", next)
+	}
+
+	c := New()
+	c.Start()
+	defer c.Stop()
+	added := time.Now()
+	fired := make(chan time.Time, 2)
+	c.AddFunc(After(100*time.Millisecond), func() { fired <- time.Now() })
+	select {
+	case at := <-fired:
+		if elapsed := at.Sub(added); elapsed < 100*time.Millisecond || elapsed > 500*time.Millisecond {
+			t.Errorf("expected the job to fire about 100ms after being added, got %v", elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("delayed job did not fire")
+	}
+	select {
+	case <-fired:
+		t.Error("expected the job to fire only once")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
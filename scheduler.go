@@ -0,0 +1,63 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Scheduler 是调度器的公共接口，*Cron是它的标准实现
+// 依赖调度器的代码可以接收Scheduler，以便在测试或禁用定时任务的环境中替换实现
+type Scheduler interface {
+	AddFunc(schedule Schedule, cmd func(), opts ...EntryOption) EntryID
+	AddJob(schedule Schedule, cmd Job, opts ...EntryOption) EntryID
+	Remove(id EntryID)
+	Start()
+	Stop() context.Context
+	Entries() []Entry
+	Location() *time.Location
+}
+
+// 确保*Cron实现了Scheduler接口
+var _ Scheduler = (*Cron)(nil)
+
+// Noop 返回一个不执行任何任务的Scheduler
+// 添加任务会返回递增的ID，但任务不会被记录也不会被触发，Start和Stop没有任何效果
+// 用于通过配置关闭定时任务，或在测试中替换真实的调度器
+func Noop() Scheduler {
+	return &noopScheduler{}
+}
+
+// noopScheduler 是Noop返回的Scheduler实现
+type noopScheduler struct {
+	nextID atomic.Int64
+}
+
+// AddFunc 实现Scheduler接口，任务不会被触发
+func (n *noopScheduler) AddFunc(Schedule, func(), ...EntryOption) EntryID {
+	return EntryID(n.nextID.Add(1))
+}
+
+// AddJob 实现Scheduler接口，任务不会被触发
+func (n *noopScheduler) AddJob(Schedule, Job, ...EntryOption) EntryID {
+	return EntryID(n.nextID.Add(1))
+}
+
+// Remove 实现Scheduler接口
+func (n *noopScheduler) Remove(EntryID) {}
+
+// Start 实现Scheduler接口
+func (n *noopScheduler) Start() {}
+
+// Stop 实现Scheduler接口，返回一个已经结束的context
+func (n *noopScheduler) Stop() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// Entries 实现Scheduler接口，总是返回nil
+func (n *noopScheduler) Entries() []Entry { return nil }
+
+// Location 实现Scheduler接口，返回本地时区
+func (n *noopScheduler) Location() *time.Location { return time.Local }
@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestNoop verifies that the noop scheduler never fires a scheduled job
+func TestNoop(t *testing.T) {
+	s := Noop()
+	var runs atomic.Int32
+	id := s.AddFunc(&ImmediateSchedule{}, func() { runs.Add(1) })
+	s.AddJob(Every(time.Millisecond), FuncJob(func() { runs.Add(1) }))
+	s.Start()
+	time.Sleep(20 * time.Millisecond)
+
+	select {
+	case <-s.Stop().Done():
+	default:
+		t.Error("expected stop context to be done")
+	}
+	if got := runs.Load(); got != 0 {
+		t.Errorf("expected no runs, got %d", got)
+	}
+	if id == 0 {
+		t.Error("expected a non-zero entry ID")
+	}
+	if entries := s.Entries(); len(entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(entries))
+	}
+}
+
+// fakeScheduler is a hand-written Scheduler recording the calls made to it
+type fakeScheduler struct {
+	schedules []Schedule
+	removed   []EntryID
+	started   bool
+}
+
+func (f *fakeScheduler) AddFunc(schedule Schedule, cmd func(), opts ...EntryOption) EntryID {
+	return f.AddJob(schedule, FuncJob(cmd), opts...)
+}
+
+func (f *fakeScheduler) AddJob(schedule Schedule, cmd Job, opts ...EntryOption) EntryID {
+	f.schedules = append(f.schedules, schedule)
+	return EntryID(len(f.schedules))
+}
+
+func (f *fakeScheduler) Remove(id EntryID)        { f.removed = append(f.removed, id) }
+func (f *fakeScheduler) Start()                   { f.started = true }
+func (f *fakeScheduler) Stop() context.Context    { return context.Background() }
+func (f *fakeScheduler) Entries() []Entry         { return nil }
+func (f *fakeScheduler) Location() *time.Location { return time.UTC }
+
+// registerCleanup is an example consumer that depends only on the Scheduler interface
+func registerCleanup(s Scheduler, interval time.Duration) EntryID {
+	id := s.AddFunc(Every(interval), func() {})
+	s.Start()
+	return id
+}
+
+// TestSchedulerFake verifies that consumers of Scheduler can be tested with a fake
+func TestSchedulerFake(t *testing.T) {
+	f := &fakeScheduler{}
+	id := registerCleanup(f, time.Minute)
+	if id != 1 {
+		t.Errorf("expected entry ID 1, got %d", id)
+	}
+	if !f.started {
+		t.Error("expected scheduler to be started")
+	}
+	if len(f.schedules) != 1 || f.schedules[0] != Every(time.Minute) {
+		t.Errorf("expected one schedule every minute, got %v", f.schedules)
+	}
+}
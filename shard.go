@@ -0,0 +1,19 @@
+package cron
+
+import "hash/fnv"
+
+// ownsShard 判断当前实例是否负责触发该任务
+// 未声明分片键或未配置分片信息时总是返回true
+func (c *Cron) ownsShard(e *Entry) bool {
+	if e.shardKey == "" || c.shardTotal <= 1 {
+		return true
+	}
+	return shardOf(e.shardKey, c.shardTotal) == c.shardIndex
+}
+
+// shardOf 计算分片键所属的分片序号
+func shardOf(key string, total int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(total))
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestShardedEntries verifies that each sharded entry fires on exactly one of two shards
+func TestShardedEntries(t *testing.T) {
+	keys := []string{"billing", "reports", "cleanup", "emails", "backup", "metrics"}
+
+	var mu sync.Mutex
+	fired := make(map[string]map[int]bool)
+	record := func(key string, shard int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if fired[key] == nil {
+			fired[key] = make(map[int]bool)
+		}
+		fired[key][shard] = true
+	}
+
+	var shards []*Cron
+	for index := 0; index < 2; index++ {
+		c := New(WithShardInfo(index, 2))
+		for _, key := range keys {
+			c.AddFunc(Every(10*time.Millisecond), func() { record(key, index) }, WithShardKey(key))
+		}
+		c.AddFunc(Every(10*time.Millisecond), func() { record("unsharded", index) })
+		shards = append(shards, c)
+		c.Start()
+	}
+	time.Sleep(100 * time.Millisecond)
+	for _, c := range shards {
+		<-c.Stop().Done()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, key := range keys {
+		if len(fired[key]) != 1 {
+			t.Errorf("entry %q fired on %d shards, expected exactly one", key, len(fired[key]))
+		}
+	}
+	if len(fired["unsharded"]) != 2 {
+		t.Errorf("unsharded entry fired on %d shards, expected both", len(fired["unsharded"]))
+	}
+}
+
+// TestWithShardInfoValidation verifies that invalid shard settings are rejected
+func TestWithShardInfoValidation(t *testing.T) {
+	for _, tc := range []struct{ index, total int }{{0, 0}, {-1, 2}, {2, 2}} {
+		if err := WithShardInfo(tc.index, tc.total)(&Cron{}); err == nil {
+			t.Errorf("expected error for index=%d total=%d", tc.index, tc.total)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package cron
+
+import (
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// conflictEpsilon 是判定两次触发属于同一时刻的最大间隔
+const conflictEpsilon = time.Millisecond
+
+// fire 表示模拟出的一次任务触发
+type fire struct {
+	id   EntryID
+	time time.Time
+}
+
+// simulate 从now开始模拟所有未暂停任务在window内的触发，按时间排序返回
+// 与主循环相同按任务已触发的次数切换分阶段调度器的阶段，并在用完MaxRuns次数后停止；
+// 调度器运行时跳过已没有下次执行时间的任务，包括已结束的任务和正在执行的固定延迟任务，
+// 同时跳过不属于当前实例分片的任务
+// 模拟使用调度器的副本和单独的随机数源，不会改变实际的调度器状态，
+// 因此带有随机性的调度器模拟结果与实际执行可能不同
+// 调用者需要持有entriesMu
+func (c *Cron) simulate(now time.Time, window time.Duration, running bool) []fire {
+	until := now.Add(window)
+	r := rand.New(rand.NewPCG(uint64(now.UnixNano()), 0))
+	var fires []fire
+	for _, e := range c.entries {
+		if e.Paused || e.exhausted() || !c.ownsShard(e) {
+			continue
+		}
+		if running && e.Next.IsZero() {
+			continue
+		}
+		// 在副本上累加触发次数，不影响实际的条目
+		sim := *e
+		sim.Schedule = simSchedule(e.Schedule, r)
+		next := sim.Next
+		if next.IsZero() {
+			next = c.simulateNext(&sim, now, r)
+		}
+		for !next.IsZero() && !next.After(until) {
+			fires = append(fires, fire{id: e.ID, time: next})
+			sim.runs++
+			if sim.exhausted() {
+				break
+			}
+			following := c.simulateNext(&sim, next, r)
+			if !following.After(next) {
+				// 不前进的调度器会导致死循环
+				break
+			}
+			next = following
+		}
+	}
+	sort.SliceStable(fires, func(i, j int) bool {
+		return fires[i].time.Before(fires[j].time)
+	})
+	return fires
+}
+
+// simulateNext 与scheduleNext相同地计算模拟中任务e在t之后的下次执行时间
+// WithAutoJitter的随机延迟使用r生成，不消耗调度器的随机数源
+func (c *Cron) simulateNext(e *Entry, t time.Time, r Rand) time.Time {
+	s := e.Schedule
+	if p, ok := s.(*PhasedSchedule); ok {
+		if s = p.phase(e.runs); s == nil {
+			return time.Time{}
+		}
+	}
+	next := s.Next(t)
+	if delay := jitterDelay(s); c.autoJitter > 0 && delay > 0 && !next.IsZero() {
+		next = next.Add(time.Duration(r.Float64() * c.autoJitter * float64(delay)))
+	}
+	return next
+}
+
+// simSchedule 返回模拟时使用的调度器
+// 有内部状态或随机数源的调度器返回使用r的副本，其余调度器没有状态，直接返回本身
+func simSchedule(s Schedule, r Rand) Schedule {
+	switch s := s.(type) {
+	case *afterSchedule:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return &afterSchedule{delay: s.delay, at: s.at}
+	case *WeightedDelaySchedule:
+		return &WeightedDelaySchedule{Delays: s.Delays, Rand: r}
+	case *RandomWithinSchedule:
+		return &RandomWithinSchedule{Period: s.Period, Rand: r}
+	case *JitterSchedule:
+		return &JitterSchedule{Schedule: simSchedule(s.Schedule, r), Max: s.Max, Rand: r}
+	case *PhasedSchedule:
+		phases := make([]Phase, len(s.Phases))
+		for i, p := range s.Phases {
+			phases[i] = Phase{Schedule: simSchedule(p.Schedule, r), MaxRuns: p.MaxRuns}
+		}
+		return &PhasedSchedule{Phases: phases}
+	}
+	return s
+}
+
+// Conflicts 模拟从当前时间起window内的所有触发，找出多个任务在同一时刻触发的情况
+// 返回的map以冲突时刻为键，值为在该时刻(误差conflictEpsilon内)触发的任务ID，按ID升序
+// 只包含至少有两个不同任务的时刻，可用于错开任务以避免负载尖峰
+func (c *Cron) Conflicts(window time.Duration) map[time.Time][]EntryID {
+	c.runningMu.Lock()
+	running := c.running
+	c.runningMu.Unlock()
+	c.entriesMu.RLock()
+	fires := c.simulate(c.now(), window, running)
+	c.entriesMu.RUnlock()
+
+	conflicts := make(map[time.Time][]EntryID)
+	for i := 0; i < len(fires); {
+		at := fires[i].time
+		seen := make(map[EntryID]bool)
+		var ids []EntryID
+		for ; i < len(fires) && fires[i].time.Sub(at) <= conflictEpsilon; i++ {
+			if !seen[fires[i].id] {
+				seen[fires[i].id] = true
+				ids = append(ids, fires[i].id)
+			}
+		}
+		if len(ids) > 1 {
+			sort.Slice(ids, func(a, b int) bool { return ids[a] < ids[b] })
+			conflicts[at] = ids
+		}
+	}
+	return conflicts
+}
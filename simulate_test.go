@@ -0,0 +1,116 @@
+package cron
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestConflicts verifies that entries firing at the same instant are grouped together
+func TestConflicts(t *testing.T) {
+	c := New()
+	a := c.AddFunc(Every(10*time.Minute), func() {})
+	b := c.AddFunc(Every(10*time.Minute), func() {})
+	c.AddFunc(Every(7*time.Minute), func() {})
+
+	conflicts := c.Conflicts(30 * time.Minute)
+
+	if len(conflicts) != 3 {
+		t.Fatalf("expected 3 conflicting instants, got %d: %v", len(conflicts), conflicts)
+	}
+	for at, ids := range conflicts {
+		if !reflect.DeepEqual(ids, []EntryID{a, b}) {
+			t.Errorf("at %v: expected entries %v, got %v", at, []EntryID{a, b}, ids)
+		}
+	}
+}
+
+// TestConflictsNone verifies that staggered entries produce no conflicts
+func TestConflictsNone(t *testing.T) {
+	c := New()
+	c.AddFunc(Every(10*time.Minute), func() {})
+	c.AddFunc(Every(7*time.Minute), func() {})
+	c.AddFunc(&ImmediateSchedule{}, func() {})
+
+	if conflicts := c.Conflicts(30 * time.Minute); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %v", conflicts)
+	}
+}
+
+// TestConflictsFinishedAndLimited verifies that finished entries are skipped and limited entries stop at their run count
+func TestConflictsFinishedAndLimited(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	a := c.AddFunc(Every(10*time.Minute), func() {})
+	b := c.AddFuncN(Every(10*time.Minute), 2, func() {})
+	once := c.AddFuncN(Every(time.Minute), 1, func() {})
+	c.AddFunc(Every(time.Minute), func() {})
+
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if e, _ := c.Entry(once); !e.Next.IsZero() {
+		t.Fatalf("expected the single-run entry to be finished, got next %v", e.Next)
+	}
+
+	conflicts := c.Conflicts(time.Hour)
+	var withB int
+	for at, ids := range conflicts {
+		if slices.Contains(ids, once) {
+			t.Errorf("at %v: unexpected finished entry %d in %v", at, once, ids)
+		}
+		if slices.Contains(ids, b) {
+			withB++
+			if !slices.Contains(ids, a) {
+				t.Errorf("at %v: expected entry %d alongside %d, got %v", at, a, b, ids)
+			}
+		}
+	}
+	if withB != 2 {
+		t.Errorf("expected the limited entry in 2 conflicts, got %d", withB)
+	}
+}
+
+// TestConflictsLeaveSchedulesUntouched verifies that simulating before Start does not fix a relative schedule's time
+func TestConflictsLeaveSchedulesUntouched(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk))
+	id := c.AddFunc(After(time.Hour), func() {})
+	c.AddFunc(Every(time.Hour), func() {})
+	if n := len(c.Conflicts(2 * time.Hour)); n != 1 {
+		t.Errorf("expected 1 simulated conflict, got %d", n)
+	}
+
+	clk.Advance(2 * time.Hour)
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+	if e, _ := c.Entry(id); !e.Next.Equal(start.Add(3 * time.Hour)) {
+		t.Errorf("expected After to count from Start, got next %v", e.Next)
+	}
+}
+
+// TestConflictsShard verifies that entries owned by another shard are not simulated
+func TestConflictsShard(t *testing.T) {
+	c := New(WithShardInfo(0, 2))
+	var key string
+	for _, k := range []string{"billing", "reports", "cleanup", "emails", "backup", "metrics"} {
+		if shardOf(k, 2) != 0 {
+			key = k
+			break
+		}
+	}
+	if key == "" {
+		t.Fatal("expected a key owned by another shard")
+	}
+	c.AddFunc(Every(10*time.Minute), func() {})
+	c.AddFunc(Every(10*time.Minute), func() {}, WithShardKey(key))
+	if conflicts := c.Conflicts(time.Hour); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts with an entry owned by another shard, got %v", conflicts)
+	}
+}
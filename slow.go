@@ -0,0 +1,26 @@
+package cron
+
+import "time"
+
+// scheduleNext 计算任务e在t之后的下次执行时间
+// 分阶段调度器按任务已触发的次数选择当前阶段
+// 设置了WithSlowScheduleThreshold且Next耗时超过阈值时记录Error日志，
+// Next在主循环中调用，耗时过长会推迟所有任务的触发
+// 设置了WithAutoJitter时为DelaySchedule附加随机延迟
+func (c *Cron) scheduleNext(e *Entry, t time.Time) time.Time {
+	s := e.Schedule
+	if p, ok := s.(*PhasedSchedule); ok {
+		if s = p.phase(e.runs); s == nil {
+			return time.Time{}
+		}
+	}
+	if c.slowSchedule <= 0 {
+		return c.jitter(s, s.Next(t))
+	}
+	start := time.Now()
+	next := s.Next(t)
+	if d := time.Since(start); d > c.slowSchedule {
+		c.logger.Error("slow schedule", "entry", e.ID, "duration", d, "threshold", c.slowSchedule)
+	}
+	return c.jitter(s, next)
+}
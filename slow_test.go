@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"bytes"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowSchedule is a schedule whose Next takes a noticeable amount of time
+type slowSchedule struct {
+	delay time.Duration
+}
+
+func (s slowSchedule) Next(t time.Time) time.Time {
+	time.Sleep(s.delay)
+	return t.Add(time.Hour)
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestSlowScheduleThreshold verifies that a slow Schedule.Next is logged with its entry ID
+func TestSlowScheduleThreshold(t *testing.T) {
+	var out syncBuffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+	c := New(WithLogger(logger), WithSlowScheduleThreshold(5*time.Millisecond))
+	slow := c.AddJob(slowSchedule{delay: 20 * time.Millisecond}, FuncJob(func() {}))
+	c.AddJob(&TestSchedule{}, FuncJob(func() {}))
+	c.Start()
+	time.Sleep(50 * time.Millisecond)
+	c.Stop()
+
+	var lines []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.Contains(line, "slow schedule") {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 slow schedule warning, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "entry="+strconv.Itoa(int(slow))) {
+		t.Errorf("expected warning for entry %d, got %q", slow, lines[0])
+	}
+}
@@ -0,0 +1,117 @@
+package cron
+
+import "time"
+
+// SpecSchedule 是基于标准cron表达式的调度器
+// 每个字段用位图表示允许的取值，第n位为1表示允许取值n
+// Second为0时等同于只允许第0秒，与5字段的cron表达式一致
+// 通常通过Parse或ParseWithSeconds创建
+type SpecSchedule struct {
+	Second, Minute, Hour, Dom, Month, Dow uint64
+}
+
+// starBit 标记日期或星期字段写的是"*"
+// 按cron惯例，日期和星期都被限定时只需满足其一，其中之一为"*"时只看另一个
+const starBit = 1 << 63
+
+// specYearLimit 是查找下一次执行时间时向后搜索的最大年数
+// 超过该年限仍找不到匹配时间(如2月30日)则返回零值
+const specYearLimit = 5
+
+// Next 计算下一次执行时间
+// 返回严格晚于t、满足所有字段的第一个整秒时间，使用t所在的时区，
+// 调度器传入的时间已转换为其配置的时区
+// 找不到匹配时间时返回零值
+func (s *SpecSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	second := s.Second
+	if second == 0 {
+		second = 1
+	}
+	// 从t之后的第一个整秒开始查找，调度器醒来较晚时结果仍对齐到整秒，不会累积偏差
+	t = t.Add(time.Second - time.Duration(t.Nanosecond()))
+
+	// 一旦某个字段发生了进位，其后的低位字段都从最小值开始
+	added := false
+	yearLimit := t.Year() + specYearLimit
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for 1<<uint(t.Month())&s.Month == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		// 夏令时切换可能使零点不存在，time.Date会将其规范化到其他小时，这里修正回零点附近
+		if t.Hour() != 0 {
+			if t.Hour() > 12 {
+				t = t.Add(time.Duration(24-t.Hour()) * time.Hour)
+			} else {
+				t = t.Add(time.Duration(-t.Hour()) * time.Hour)
+			}
+		}
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Hour())&s.Hour == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Minute())&s.Minute == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	for 1<<uint(t.Second())&second == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Second)
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t
+}
+
+// dayMatches 判断t的日期是否同时满足日期和星期字段
+func (s *SpecSchedule) dayMatches(t time.Time) bool {
+	domMatch := 1<<uint(t.Day())&s.Dom > 0
+	dowMatch := 1<<uint(t.Weekday())&s.Dow > 0
+	if s.Dom&starBit > 0 || s.Dow&starBit > 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
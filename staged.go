@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// AddStaged 添加一个分阶段执行的定时任务
+// 每次触发时按顺序执行stages: 同一阶段内的任务并发执行，全部完成后才开始下一阶段
+// 某个阶段有任务返回错误或panic时，后续阶段不再执行，错误合并后作为本次执行的错误返回
+// 注意: 阶段内最慢的任务会推迟所有后续阶段，整个执行过程占用一次任务执行
+// 任一阶段中有nil任务时任务不会被添加
+func (c *Cron) AddStaged(schedule Schedule, stages [][]Job, opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, stagedJob(stages), opts...)
+}
+
+// stagedJob 是AddStaged添加的任务
+type stagedJob [][]Job
+
+// Run 实现Job接口
+func (s stagedJob) Run() {
+	_ = s.runContext(context.Background())
+}
+
+// isNil 实现nilChecker接口，任一阶段中有nil任务时视为无效
+func (s stagedJob) isNil() bool {
+	for _, stage := range s {
+		if slices.ContainsFunc(stage, func(j Job) bool { return isNilJob(j) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// runContext 依次执行每个阶段，遇到失败的阶段时停止
+func (s stagedJob) runContext(ctx context.Context) error {
+	for i, stage := range s {
+		if err := runStage(ctx, stage); err != nil {
+			return fmt.Errorf("stage %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// runStage 并发执行一个阶段的所有任务并等待它们完成
+// 任务的panic会被捕获并转换为错误
+func runStage(ctx context.Context, jobs []Job) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(jobs))
+	for i, j := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					errs[i] = fmt.Errorf("job panic: %v", r)
+				}
+			}()
+			errs[i] = invoke(ctx, j)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
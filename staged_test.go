@@ -0,0 +1,59 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestStagedOrder verifies that a stage starts only after every job of the previous stage finished
+func TestStagedOrder(t *testing.T) {
+	var finished atomic.Int32
+	var mu sync.Mutex
+	var seen []int32
+	first := func(d time.Duration) Job {
+		return FuncJob(func() {
+			time.Sleep(d)
+			finished.Add(1)
+		})
+	}
+	second := FuncJob(func() {
+		mu.Lock()
+		seen = append(seen, finished.Load())
+		mu.Unlock()
+	})
+
+	job := stagedJob{
+		{first(10 * time.Millisecond), first(30 * time.Millisecond), first(20 * time.Millisecond)},
+		{second, second},
+	}
+	if err := job.runContext(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected 2 second-stage runs, got %d", len(seen))
+	}
+	for _, n := range seen {
+		if n != 3 {
+			t.Errorf("second stage started after %d of 3 first-stage jobs finished", n)
+		}
+	}
+}
+
+// TestStagedFailure verifies that a failing stage stops later stages
+func TestStagedFailure(t *testing.T) {
+	var ran bool
+	job := stagedJob{
+		{errorJob{job: ErrorFuncJob(func() error { return errors.New("failed") })}, FuncJob(func() { panic("boom") })},
+		{FuncJob(func() { ran = true })},
+	}
+	if err := job.runContext(context.Background()); err == nil {
+		t.Error("expected error from failing stage")
+	}
+	if ran {
+		t.Error("expected later stage to be skipped")
+	}
+}
@@ -0,0 +1,15 @@
+package cron
+
+// Stats 是调度器运行统计的快照
+type Stats struct {
+	Panics     int64 // 累计panic次数，仅在panic策略为PanicCount或CrashAfter时统计
+	LeakedJobs int64 // 超过WithHardDeadlineLog截止时间和宽限期仍未结束的执行次数
+}
+
+// Stats 返回调度器当前的运行统计
+func (c *Cron) Stats() Stats {
+	return Stats{
+		Panics:     c.panics.Load(),
+		LeakedJobs: c.leakedJobs.Load(),
+	}
+}
@@ -0,0 +1,101 @@
+package cron
+
+import "context"
+
+// SuspendWhile 在ctx结束前暂停所有任务的触发，ctx结束后自动恢复
+// 暂停期间仍可添加和删除任务，到期的任务不会排队，恢复时所有任务从当前时间重新计算下次执行时间
+// 可以同时使用多个ctx，全部结束后才会恢复
+// 会启动一个等待ctx结束的goroutine，调度器Stop时该goroutine同样退出并解除本次暂停，
+// 因此ctx永远不结束也不会泄漏goroutine
+func (c *Cron) SuspendWhile(ctx context.Context) {
+	c.runningMu.Lock()
+	stopping := c.stopping
+	c.runningMu.Unlock()
+	c.suspend()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopping:
+		}
+		c.unsuspend()
+	}()
+}
+
+// Pause 暂停所有任务的触发，直到调用Resume
+// 暂停期间仍可添加和删除任务，到期的任务不会排队；重复调用没有额外效果
+// 与SuspendWhile共用全局暂停，两者都解除后才会恢复触发
+func (c *Cron) Pause() {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if c.paused {
+		return
+	}
+	c.paused = true
+	c.suspendLocked()
+}
+
+// Resume 解除Pause的暂停，恢复时所有任务从当前时间重新计算下次执行时间
+// 与ResumeEntry相同，可以传入ResumeOptions让暂停期间错过执行的任务立即触发一次，传入多个时以最后一个为准；
+// SuspendWhile的暂停仍未解除时选项不生效
+// 未暂停时没有效果
+func (c *Cron) Resume(opts ...ResumeOptions) {
+	var opt ResumeOptions
+	for _, o := range opts {
+		opt = o
+	}
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if !c.paused {
+		return
+	}
+	c.paused = false
+	c.unsuspendLocked(opt)
+}
+
+// suspend 增加一层全局暂停
+func (c *Cron) suspend() {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	c.suspendLocked()
+}
+
+// suspendLocked 增加一层全局暂停，调用者需要持有entriesMu
+// Pause在同一个临界区内设置paused，避免并发的Pause和Resume使两者不一致
+func (c *Cron) suspendLocked() {
+	c.suspended++
+	c.logger.Info("suspended")
+	c.wakeUp()
+}
+
+// unsuspend 解除一层全局暂停，全部解除时从当前时间重新计算所有任务的下次执行时间
+func (c *Cron) unsuspend() {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	c.unsuspendLocked(ResumeOptions{})
+}
+
+// unsuspendLocked 与unsuspend相同，全部解除时按opt处理暂停期间错过执行的任务
+// 调用者需要持有entriesMu
+func (c *Cron) unsuspendLocked(opt ResumeOptions) {
+	if c.suspended == 0 {
+		return
+	}
+	c.suspended--
+	if c.suspended > 0 {
+		return
+	}
+	now := c.now()
+	for _, e := range c.entries {
+		if !e.active() {
+			continue
+		}
+		if opt.RunIfMissed && !e.Next.After(now) {
+			// 与ResumeEntry相同，交给主循环立即触发一次
+			e.Next = now
+		} else {
+			e.Next = c.scheduleNext(e, now)
+		}
+	}
+	c.logger.Info("resumed", "now", now)
+	c.wakeUp()
+}
@@ -0,0 +1,185 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestSuspendWhile verifies that jobs do not fire until the suspending context is cancelled
+func TestSuspendWhile(t *testing.T) {
+	clk := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := New(WithClock(clk))
+	var runs atomic.Int32
+	c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.SuspendWhile(ctx)
+	for range 5 {
+		clk.Advance(time.Minute)
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("expected no runs while suspended, got %d", got)
+	}
+
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	clk.BlockUntil(1)
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("expected no catch-up flood on resume, got %d", got)
+	}
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	<-c.Stop().Done()
+	if got := runs.Load(); got != 1 {
+		t.Errorf("expected 1 run after resuming, got %d", got)
+	}
+}
+
+// TestPauseResume verifies that a paused scheduler skips due runs and fires once after resuming
+func TestPauseResume(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	var runs atomic.Int32
+	id := c.AddFunc(Every(time.Minute), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	c.Pause()
+	c.Pause()
+	clk.BlockUntil(1)
+	clk.Advance(3 * time.Minute)
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if got := runs.Load(); got != 0 {
+		t.Fatalf("expected no runs while paused, got %d", got)
+	}
+
+	c.Resume()
+	if e, _ := c.Entry(id); !e.Next.Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("expected next run recomputed from resume time, got %v", e.Next)
+	}
+	// the loop may still be waiting on its idle timer; a timer armed after the
+	// advance fires immediately because it is measured from the current time
+	clk.Advance(time.Minute)
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if got := runs.Load(); got != 1 {
+		t.Errorf("expected exactly 1 run after resuming, got %d", got)
+	}
+	c.Resume()
+}
+
+// TestPauseResumePhased verifies that resuming keeps a phased entry in its current phase
+func TestPauseResumePhased(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	var runs atomic.Int32
+	id := c.AddFunc(SchedulePhases([]Phase{
+		{Schedule: Every(time.Minute), MaxRuns: 1},
+		{Schedule: Every(time.Hour)},
+	}), func() { runs.Add(1) })
+	c.Start()
+	defer c.Stop()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if got := runs.Load(); got != 1 {
+		t.Fatalf("expected the first phase to run once, got %d", got)
+	}
+
+	c.Pause()
+	c.Resume()
+	want := start.Add(time.Minute + time.Hour)
+	if e, _ := c.Entry(id); !e.Next.Equal(want) {
+		t.Errorf("expected next run from the second phase at %v after Resume, got %v", want, e.Next)
+	}
+
+	c.PauseEntry(id)
+	c.ResumeEntry(id)
+	if e, _ := c.Entry(id); !e.Next.Equal(want) {
+		t.Errorf("expected next run from the second phase at %v after ResumeEntry, got %v", want, e.Next)
+	}
+	if fires := c.simulate(start.Add(time.Minute), 3*time.Hour, true); len(fires) != 3 {
+		t.Errorf("expected 3 simulated hourly fires, got %d", len(fires))
+	}
+}
+
+// TestPauseResumeConcurrent verifies that racing Pause and Resume calls keep the flag and counter in step
+func TestPauseResumeConcurrent(t *testing.T) {
+	c := New()
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				c.Pause()
+			} else {
+				c.Resume()
+			}
+		}()
+	}
+	wg.Wait()
+	c.Resume()
+
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	if c.paused || c.suspended != 0 {
+		t.Errorf("expected scheduler to be fully resumed, got paused %v suspended %d", c.paused, c.suspended)
+	}
+}
+
+// TestPauseResumeRunIfMissed verifies that Resume can fire entries that came due while paused
+func TestPauseResumeRunIfMissed(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clk := NewFakeClock(start)
+	c := New(WithClock(clk), WithLocation(time.UTC))
+	var missed, pending atomic.Int32
+	missedID := c.AddFunc(Every(time.Minute), func() { missed.Add(1) })
+	pendingID := c.AddFunc(Every(time.Hour), func() { pending.Add(1) })
+	c.Start()
+	defer c.Stop()
+	clk.BlockUntil(1)
+
+	c.Pause()
+	clk.BlockUntil(1)
+	clk.Advance(3 * time.Minute)
+	clk.BlockUntil(1)
+
+	now := start.Add(3 * time.Minute)
+	c.Resume(ResumeOptions{RunIfMissed: true})
+	deadline := time.Now().Add(time.Second)
+	for missed.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	clk.BlockUntil(1)
+	c.WaitJobs()
+	if got := missed.Load(); got != 1 {
+		t.Errorf("expected the missed entry to run once on resume, got %d", got)
+	}
+	if got := pending.Load(); got != 0 {
+		t.Errorf("expected the entry not yet due to wait, got %d runs", got)
+	}
+	if e, _ := c.Entry(missedID); !e.Next.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected missed entry to continue from the resume time, got %v", e.Next)
+	}
+	if e, _ := c.Entry(pendingID); !e.Next.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected pending entry to be recomputed from the resume time, got %v", e.Next)
+	}
+}
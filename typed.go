@@ -0,0 +1,42 @@
+package cron
+
+import "context"
+
+// TypedJob 是产生类型为T的结果的任务
+// 每次执行成功后将Fn的返回值发送到Results
+// 发送是非阻塞的: Results已满或无人接收时本次结果会被丢弃，不会阻塞调度器
+// 需要保留每次结果时请为Results设置足够的缓冲
+// Fn返回错误时不发送结果，错误会像ErrorJob一样记录日志
+type TypedJob[T any] struct {
+	Fn      func() (T, error)
+	Results chan<- T
+}
+
+// Run 实现Job接口
+func (j TypedJob[T]) Run() {
+	_ = j.runContext(context.Background())
+}
+
+// isNil 实现nilChecker接口，Fn为nil时任务无法执行
+func (j TypedJob[T]) isNil() bool {
+	return j.Fn == nil
+}
+
+// runContext 执行Fn并投递结果
+func (j TypedJob[T]) runContext(context.Context) error {
+	v, err := j.Fn()
+	if err != nil {
+		return err
+	}
+	select {
+	case j.Results <- v:
+	default:
+	}
+	return nil
+}
+
+// AddTypedJob 添加一个产生类型为T的结果的任务，结果以非阻塞方式发送到results
+// Go不支持泛型方法，因此以函数形式提供
+func AddTypedJob[T any](c *Cron, schedule Schedule, fn func() (T, error), results chan<- T, opts ...EntryOption) EntryID {
+	return c.AddJob(schedule, TypedJob[T]{Fn: fn, Results: results}, opts...)
+}
@@ -0,0 +1,62 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestAddTypedJob verifies that each run delivers its value on the results channel
+func TestAddTypedJob(t *testing.T) {
+	c := New()
+	results := make(chan int, 10)
+	n := 0
+	AddTypedJob(c, Every(20*time.Millisecond), func() (int, error) {
+		n++
+		return n, nil
+	}, results)
+	c.Start()
+	defer c.Stop()
+
+	for want := 1; want <= 3; want++ {
+		select {
+		case got := <-results:
+			if got != want {
+				t.Errorf("expected result %d, got %d", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("result %d was not delivered", want)
+		}
+	}
+}
+
+// TestTypedJobNonBlocking verifies that results are dropped instead of blocking and errors are not delivered
+func TestTypedJobNonBlocking(t *testing.T) {
+	results := make(chan int, 1)
+	job := TypedJob[int]{Fn: func() (int, error) { return 1, nil }, Results: results}
+
+	done := make(chan struct{})
+	go func() {
+		job.Run()
+		job.Run()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("run blocked on a full results channel")
+	}
+	if len(results) != 1 {
+		t.Errorf("expected 1 buffered result, got %d", len(results))
+	}
+
+	<-results
+	failing := TypedJob[int]{Fn: func() (int, error) { return 0, errors.New("boom") }, Results: results}
+	if err := failing.runContext(context.Background()); err == nil {
+		t.Error("expected error from failing job")
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no result from a failing job, got %d", len(results))
+	}
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Validate 检查所有已注册任务的调度器能否给出有效的下次执行时间
+// 调度器为nil、Next发生panic、返回零值或不晚于当前时间的任务都被视为无效，
+// 返回的错误通过errors.Join合并了所有无效任务的错误，每个错误都包含任务ID并包装ErrInvalidSchedule
+// 依赖任务没有自己的调度，不参与检查
+func (c *Cron) Validate() error {
+	c.entriesMu.RLock()
+	defer c.entriesMu.RUnlock()
+	now := c.now()
+	var errs []error
+	for _, e := range c.entries {
+		if e.parent != 0 {
+			continue
+		}
+		if err := validateSchedule(e.Schedule, now); err != nil {
+			errs = append(errs, fmt.Errorf("entry %d: %w", e.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateSchedule 检查调度器在now之后能否给出有效的下次执行时间
+func validateSchedule(s Schedule, now time.Time) (err error) {
+	if s == nil {
+		return fmt.Errorf("%w: schedule is nil", ErrInvalidSchedule)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: schedule panicked: %v", ErrInvalidSchedule, r)
+		}
+	}()
+	next := s.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("%w: schedule never fires", ErrInvalidSchedule)
+	}
+	if !next.After(now) {
+		return fmt.Errorf("%w: next time %v does not progress past %v", ErrInvalidSchedule, next, now)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// panicSchedule panics whenever Next is called
+type panicSchedule struct{}
+
+func (panicSchedule) Next(time.Time) time.Time { panic("broken schedule") }
+
+// TestValidate verifies that Validate reports only the invalid entries
+func TestValidate(t *testing.T) {
+	c := New()
+	c.AddFunc(Every(time.Minute), func() {})
+	c.AddFunc(&TestSchedule{}, func() {})
+	bad := c.AddFunc(panicSchedule{}, func() {})
+	stuck := c.AddFunc(&ImmediateSchedule{}, func() {})
+
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("expected error to wrap ErrInvalidSchedule, got %v", err)
+	}
+	msg := err.Error()
+	for _, id := range []EntryID{bad, stuck} {
+		if want := fmt.Sprintf("entry %d:", id); !strings.Contains(msg, want) {
+			t.Errorf("expected error to mention %q, got %q", want, msg)
+		}
+	}
+	if strings.Contains(msg, "entry 1:") || strings.Contains(msg, "entry 2:") {
+		t.Errorf("valid entries should not be reported, got %q", msg)
+	}
+}
+
+// TestValidateOnStart verifies that an invalid scheduler refuses to start
+func TestValidateOnStart(t *testing.T) {
+	c := New(WithValidateOnStart())
+	c.AddFunc(panicSchedule{}, func() {})
+	c.Start()
+
+	c.runningMu.Lock()
+	running := c.running
+	c.runningMu.Unlock()
+	if running {
+		c.Stop()
+		t.Fatal("scheduler started despite failing validation")
+	}
+
+	ok := New(WithValidateOnStart())
+	ok.AddFunc(Every(time.Minute), func() {})
+	if err := ok.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	ok.Start()
+	defer ok.Stop()
+	ok.runningMu.Lock()
+	defer ok.runningMu.Unlock()
+	if !ok.running {
+		t.Error("valid scheduler did not start")
+	}
+}